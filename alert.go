@@ -1,13 +1,16 @@
 package bubbleup
 
 import (
+	"errors"
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/lucasb-eyer/go-colorful"
+	"github.com/muesli/reflow/ansi"
 )
 
 // Alert keys for the included alert types.
@@ -55,6 +58,10 @@ const (
 // Defaults used by the notification rendering.
 const (
 	DefaultLerpIncrement = 0.18
+	DefaultExpandKey     = "ctrl+e"
+	DefaultEllipsis      = "…"
+	DefaultFlashBlend    = 0.6
+	DefaultTickInterval  = time.Millisecond * 100
 )
 
 // Colors used by the included alert types.
@@ -64,6 +71,7 @@ const (
 	ErrorColor = "#FF0000"
 	DebugColor = "#FF00FF"
 	BackColor  = "#000000"
+	FlashColor = "#FFFFFF"
 )
 
 // Constant colors and stylings used for included alert types.
@@ -74,10 +82,25 @@ var (
 	errorColor, _ = colorful.Hex(ErrorColor)
 	debugColor, _ = colorful.Hex(DebugColor)
 	backColor, _  = colorful.Hex(BackColor)
+	flashColor, _ = colorful.Hex(FlashColor)
 
-	baseStyle = lipgloss.NewStyle().BorderStyle(lipgloss.RoundedBorder())
+	baseStyle  = lipgloss.NewStyle().BorderStyle(lipgloss.RoundedBorder())
+	linedStyle = lipgloss.NewStyle().Border(lipgloss.ThickBorder(), false, false, false, true)
 )
 
+// ASCIIBorder draws alert frames with plain ASCII characters, for terminals
+// that garble the default box-drawing glyphs. Pass it to WithBorder.
+var ASCIIBorder = lipgloss.Border{
+	Top:         "-",
+	Bottom:      "-",
+	Left:        "|",
+	Right:       "|",
+	TopLeft:     "+",
+	TopRight:    "+",
+	BottomLeft:  "+",
+	BottomRight: "+",
+}
+
 var parsedColors = map[string]colorful.Color{
 	InfoColor:  infoColor,
 	WarnColor:  warnColor,
@@ -86,83 +109,317 @@ var parsedColors = map[string]colorful.Color{
 	BackColor:  backColor,
 }
 
+// AlertID identifies an individual alert so it can be targeted by
+// commands after it has been shown. Alerts created by NewAlertCmd have
+// no ID and can't be targeted.
+type AlertID string
+
+// AlertSpec fully describes an alert to be shown.
+type AlertSpec struct {
+	// (Opt) ID used to target the alert with later commands
+	ID AlertID
+
+	// (Req) Key of the alert type to show
+	Key string
+
+	// (Req) Message to display
+	Message string
+
+	// (Opt) How long the alert displays, in seconds. Defaults to the model's duration.
+	Duration time.Duration
+
+	// (Opt) Arbitrary app context carried with the alert, such as a request id.
+	// It is never rendered, but is passed back to the OnDismiss hook.
+	Metadata map[string]any
+
+	// (Opt) Keep the message's own line breaks instead of wrapping it, for
+	// pre-formatted text. Lines wider than the alert are cut with an ellipsis.
+	NoWrap bool
+
+	// (Opt) Single line shown under the first line of the message, cut with
+	// an ellipsis rather than wrapped. See NewAlertWithSubtitleCmd.
+	Subtitle string
+
+	// (Opt) Font mode for this alert's prefix: FontNerd, FontUnicode or
+	// FontASCII. Defaults to the model's font mode.
+	Font string
+
+	// (Opt) Command Update returns when the alert is clicked, e.g. to open
+	// the related file. Ctrl+click dismisses the alert instead.
+	OnClick tea.Cmd
+}
+
+// parseColor returns the color for hex, which must already have been validated.
+func parseColor(hex string) colorful.Color {
+	color, ok := parsedColors[hex]
+	if !ok {
+		// Can safely discard error because we validated the color
+		// when registering the alert defition
+		color, _ = colorful.Hex(hex)
+	}
+	return color
+}
+
 // alertMsg is the tea.Msg used to activate a notification
 type alertMsg struct {
-	alertKey string
-	msg      string
-	dur      time.Duration
+	id        AlertID
+	alertKey  string
+	msg       string
+	subtitle  string
+	font      string
+	onClick   tea.Cmd
+	dur       time.Duration
+	metadata  map[string]any
+	sticky    bool
+	sequenced bool
+	target    *Point
+	noWrap    bool
+	inboxed   bool
+	woken     bool
+	created   bool // AlertCreated was published, so this is a re-send
+	confirm   *confirmation
+	until     func(tea.Msg) bool
 
 	// TODO:
 	// animation: how the notification should appear and disappear
 	// style: Mimic nvim.notify's style options perhaps?
 }
 
-func (m AlertModel) newAlert(key, msg string, dur time.Duration) *alert {
-	if msg == "" || key == "" {
+// statusMsg is the tea.Msg used to change a status alert in place
+type statusMsg struct {
+	id       AlertID
+	alertKey string
+	msg      string
+}
+
+// appendMsg is the tea.Msg used to append text to an alert's message
+type appendMsg struct {
+	id   AlertID
+	text string
+}
+
+// spec returns the AlertSpec describing the alert msg would raise.
+func (msg alertMsg) spec() AlertSpec {
+	return AlertSpec{
+		ID:       msg.id,
+		Key:      msg.alertKey,
+		Message:  msg.msg,
+		Duration: msg.dur / time.Second,
+		Metadata: msg.metadata,
+		NoWrap:   msg.noWrap,
+		Subtitle: msg.subtitle,
+		Font:     msg.font,
+		OnClick:  msg.onClick,
+	}
+}
+
+// badgeMsg is the tea.Msg used to set the badge count of an alert
+type badgeMsg struct {
+	id    AlertID
+	count int
+}
+
+func (m AlertModel) newAlert(msg alertMsg) *alert {
+	if msg.msg == "" || msg.alertKey == "" {
 		return nil
 	}
 
-	alertDef, ok := m.alertTypes[key]
+	alertDef, ok := m.alertTypes[msg.alertKey]
 
 	if !ok {
 		return nil
 	}
 
-	foreColor, ok := parsedColors[alertDef.ForeColor]
-	if !ok {
-		// Can safely discard error because we validated the color
-		// when registering the alert defition
-		foreColor, _ = colorful.Hex(alertDef.ForeColor)
+	foreColor := parseColor(alertDef.ForeColor)
+
+	// Border follows the alert type's color unless the definition
+	// overrides it and the model doesn't force them to match.
+	borderColor := foreColor
+	if alertDef.BorderColor != "" && !m.borderMatchesType {
+		borderColor = parseColor(alertDef.BorderColor)
 	}
 
-	return &alert{
-		message:     msg,
-		deathTime:   time.Now().Add(dur),
-		prefix:      alertDef.Prefix,
+	// Icon and text fall back to the alert type's color when unset
+	iconColor, textColor := foreColor, foreColor
+	if alertDef.IconColor != "" {
+		iconColor = parseColor(alertDef.IconColor)
+	}
+	if alertDef.TextColor != "" {
+		textColor = parseColor(alertDef.TextColor)
+	}
+	iconColor = ensureContrast(iconColor, backColor, m.minContrast)
+	textColor = ensureContrast(textColor, backColor, m.minContrast)
+
+	width, minWidth := m.alertWidths()
+	n := &alert{
+		id:          msg.id,
+		key:         msg.alertKey,
+		message:     msg.msg,
+		subtitle:    msg.subtitle,
+		confirm:     msg.confirm,
+		count:       1,
+		dur:         msg.dur,
+		metadata:    msg.metadata,
+		sticky:      msg.sticky,
+		sequenced:   msg.sequenced,
+		target:      msg.target,
+		noWrap:      msg.noWrap,
+		until:       msg.until,
+		birthTime:   m.getClock().Now(),
+		deathTime:   m.getClock().Now().Add(msg.dur + m.dismissOnIdle),
+		prefix:      m.prefixFor(alertDef, msg.font),
+		pillEnds:    m.pillEnds(msg.font),
+		font:        msg.font,
+		onClick:     msg.onClick,
 		foreColor:   foreColor,
+		iconColor:   iconColor,
+		textColor:   textColor,
+		borderColor: borderColor,
 		style:       alertDef.Style,
-		width:       m.width,
-		minWidth:    m.minWidth,
+		kind:        alertDef.Kind,
+		width:       width,
+		minWidth:    minWidth,
+		textWidth:   m.textWidth,
+		lineLimit:   m.lineLimit,
+		ellipsis:    m.getEllipsis(),
+		border:      m.border,
+		flashPhases: m.flashPhases(msg.alertKey),
 		curLerpStep: 0.3,
+		easing:      m.easing,
 		position:    m.position,
 	}
-
+	if msg.confirm != nil {
+		n.position = TopCenterPosition
+	}
+	if m.testMode {
+		// No ticks will animate it, so start settled
+		n.curLerpStep = 1
+		n.flashPhases = 0
+	}
+	return n
 }
 
 // alert represents an instance of an actual alert, including
 // all information needed to render and destroy itself
 type alert struct {
-	message   string
-	deathTime time.Time
-	prefix    string
-	foreColor colorful.Color
-	style     lipgloss.Style
-	width     int
-	minWidth  int
+	id            AlertID
+	key           string
+	escalatedFrom string
+	message       string
+	subtitle      string
+	font          string
+	onClick       tea.Cmd
+	confirm       *confirmation
+	confirmYes    bool
+	dismissArmed  bool
+	count         int
+	dur           time.Duration
+	metadata      map[string]any
+	sticky        bool
+	sequenced     bool
+	target        *Point
+	noWrap        bool
+	until         func(tea.Msg) bool
+	birthTime     time.Time
+	deathTime     time.Time
+	focusBoost    time.Duration
+	hoveredAt     time.Time
+	prefix        string
+	foreColor     colorful.Color
+	iconColor     colorful.Color
+	textColor     colorful.Color
+	borderColor   colorful.Color
+	style         lipgloss.Style
+	kind          AlertKind
+	pillEnds      [2]string
+	width         int
+	minWidth      int
+	textWidth     int
+	lineLimit     int
+	ellipsis      string
+	border        *lipgloss.Border
+	expanded      bool
+	following     bool
+	flashPhases   int
+	spinner       []string
+	spinFrame     int
 
 	curLerpStep float64
+	easing      func(t float64) float64
 	position    Position
+
+	// WithEdgeInset cells the alert was last drawn in by, since that depends
+	// on the content passed to Render
+	inset int
+
+	// Last rendering, reused while its inputs are unchanged
+	rendered    string
+	renderedKey renderKey
+}
+
+// renderKey holds every input that affects an alert's rendering.
+type renderKey struct {
+	message, subtitle, prefix, ellipsis     string
+	count                                   int
+	width, minWidth, textWidth, limit       int
+	noWrap, expanded, following, yes, armed bool
+	kind                                    AlertKind
+	ends                                    [2]string
+	border                                  *lipgloss.Border
+	iconColor, textColor, borderColor       lipgloss.Color
+}
+
+// renderInputs returns the renderKey for the alert's current state.
+func (n *alert) renderInputs() renderKey {
+	return renderKey{
+		message: n.message, subtitle: n.subtitle, prefix: n.icon(), ellipsis: n.ellipsis,
+		count: n.count,
+		width: n.width, minWidth: n.minWidth, textWidth: n.textWidth, limit: n.lineLimit,
+		noWrap: n.noWrap, expanded: n.expanded, following: n.following, yes: n.confirmYes, armed: n.dismissArmed,
+		kind:      n.kind,
+		ends:      n.pillEnds,
+		border:    n.border,
+		iconColor: n.fade(n.iconColor), textColor: n.fade(n.textColor), borderColor: n.fade(n.borderColor),
+	}
 }
 
 // render will render the given alert based on its values
 // Returns the string representation of the alert, ready to be
 // overlayed onto the main content.
 func (n *alert) render() string {
-	newColor := backColor.BlendLab(n.foreColor, n.curLerpStep)
-	lipColor := lipgloss.Color(newColor.Hex())
+	key := n.renderInputs()
+	iconLipColor, textLipColor, borderLipColor := key.iconColor, key.textColor, key.borderColor
+	if n.rendered != "" && n.renderedKey == key {
+		return n.rendered
+	}
+	if n.kind == KindPill {
+		n.rendered, n.renderedKey = n.renderPill(n.width, iconLipColor, textLipColor, borderLipColor), key
+		return n.rendered
+	}
+
+	badge := n.badge()
+	prefix := n.icon()
+	if badge != "" {
+		prefix += " " + badge
+	}
 
-	// Calculate actual width based on minWidth setting
+	// Calculate actual width based on minWidth setting; unwrapped alerts
+	// always size to their widest line
 	actualWidth := n.width // default to max/fixed width
 
-	if n.minWidth > 0 {
-		// Dynamic mode: measure message width
-		messageText := fmt.Sprintf("%v %v", n.prefix, n.message)
+	message := n.layoutMessage(n.subtitle)
 
-		// Get the width of the message text itself
-		messageWidth := lipgloss.Width(messageText)
+	if n.minWidth > 0 || n.noWrap {
+		// Dynamic mode: measure the message exactly as it is laid out, with
+		// the badge and the hanging indent of any further lines
+		measured := message
+		if footer := n.footer(); footer != "" {
+			measured += "\n" + footer
+		}
+		messageWidth := lipgloss.Width(hangingLines(prefix, measured, n.width, n.ellipsis))
 
-		// Account for extra space needed, determined imperically
-		messageWidth += 3
+		// Account for the padding on either side
+		messageWidth += 2
 
 		// Clamp between min and max
 		if messageWidth < n.minWidth {
@@ -174,39 +431,307 @@ func (n *alert) render() string {
 		}
 	}
 
-	newStyle := baseStyle.
-		Foreground(lipColor).
-		BorderForeground(lipColor).
-		Width(actualWidth).
-		Padding(0, 1)
+	newStyle := n.frameStyle(textLipColor, borderLipColor).
+		Width(actualWidth)
 
 	// Compute width available for text inside border+padding.
 	textWidth := actualWidth - 2
+	if n.textWidth > 0 && n.textWidth < textWidth {
+		// Clamped so text never exceeds the box's content width
+		textWidth = n.textWidth
+	}
 	if textWidth < 1 {
 		textWidth = 1
 	}
 
-	content := hangingWrap(n.prefix, n.message, textWidth)
-	return newStyle.Render(content)
+	if n.subtitle != "" {
+		// The subtitle never wraps, so cut it to fit beside the indent
+		message = n.layoutMessage(truncate(n.subtitle, textWidth-lipgloss.Width(prefix+" "), n.ellipsis))
+	}
+
+	content := hangingWrap(prefix, message, textWidth)
+	if n.noWrap {
+		content = hangingLines(prefix, message, textWidth, n.ellipsis)
+	}
+	if n.lineLimit > 0 && !n.expanded {
+		if n.following {
+			content = limitLinesTail(content, n.lineLimit, prefix+" ", textWidth, n.ellipsis)
+		} else {
+			content = limitLines(content, n.lineLimit, lipgloss.Width(prefix+" "), textWidth, n.ellipsis)
+		}
+	}
+	if footer := n.footer(); footer != "" {
+		// Added after the line limit, so the buttons and hint are never cut
+		lines := hangingWrap(prefix, "\n"+footer, textWidth)
+		if n.noWrap {
+			lines = hangingLines(prefix, "\n"+footer, textWidth, n.ellipsis)
+		}
+		_, lines, _ = strings.Cut(lines, "\n")
+		content += "\n" + lines
+	}
+	if badge != "" || n.iconColor != n.textColor {
+		content = styleHead(content, n.icon()+" ", badge, iconLipColor, textLipColor)
+	}
+	// Styled content can be measured differently by lipgloss than by the
+	// terminal, so force every line of the box to the width it should have
+	box := fitLines(newStyle.Render(content), actualWidth+newStyle.GetHorizontalBorderSize())
+	n.rendered, n.renderedKey = box, key
+	return n.rendered
+}
+
+// truncated reports whether the line limit cut any of the alert's lines, so
+// the expand key has something to show.
+func (n *alert) truncated() bool {
+	if n.lineLimit <= 0 || n.expanded {
+		return false
+	}
+	full := *n
+	full.expanded = true
+	return lipgloss.Height(full.render()) > lipgloss.Height(n.render())
+}
+
+// layoutMessage returns the message with the given subtitle, if the alert
+// has one.
+func (n *alert) layoutMessage(subtitle string) string {
+	if n.subtitle == "" {
+		return n.message
+	}
+	return withSubtitle(n.message, subtitle)
+}
+
+// footer returns the lines shown under the message: the buttons of a
+// confirm alert and any dismiss hint. It's "" when there are none.
+func (n *alert) footer() string {
+	var lines []string
+	if n.confirm != nil {
+		lines = append(lines, n.confirmButtons())
+	}
+	if n.dismissArmed {
+		lines = append(lines, dismissAgainHint)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// spec returns the AlertSpec describing the alert.
+func (n *alert) spec() AlertSpec {
+	return AlertSpec{
+		ID:       n.id,
+		Key:      n.key,
+		Message:  n.message,
+		Duration: n.dur / time.Second,
+		Metadata: n.metadata,
+		NoWrap:   n.noWrap,
+		Subtitle: n.subtitle,
+		Font:     n.font,
+		OnClick:  n.onClick,
+	}
+}
+
+// expiredAt reports whether the alert should be gone at t, either because
+// its timer ran out or because it has outlived maxLifetime (when > 0).
+// Sticky alerts never expire, and the timer is paused while hovered.
+func (n *alert) expiredAt(t time.Time, maxLifetime time.Duration) bool {
+	if n.sticky {
+		return false
+	}
+	if n.hoveredAt.IsZero() && n.deathTime.Before(t) {
+		return true
+	}
+	return maxLifetime > 0 && !n.birthTime.Add(maxLifetime).After(t)
+}
+
+// frameStyle returns the style framing the alert's content, according
+// to the alert's kind.
+func (n *alert) frameStyle(fg, border lipgloss.Color) lipgloss.Style {
+	var style lipgloss.Style
+	switch n.kind {
+	case KindLined:
+		style = linedStyle
+	case KindBare:
+		style = lipgloss.NewStyle()
+	default:
+		style = baseStyle
+	}
+	if n.border != nil && n.kind != KindBare {
+		style = style.BorderStyle(*n.border)
+	}
+
+	return style.
+		Foreground(fg).
+		BorderForeground(border).
+		Padding(0, 1)
+}
+
+// renderCompact renders the alert as just its icon and the first word
+// of its message, followed by an ellipsis when anything was left out.
+// The box is kept within maxWidth columns including its border.
+func (n *alert) renderCompact(maxWidth int) string {
+	iconLipColor := n.fade(n.iconColor)
+	textLipColor := n.fade(n.textColor)
+	borderLipColor := n.fade(n.borderColor)
+
+	if n.kind == KindPill {
+		return n.renderPill(maxWidth, iconLipColor, textLipColor, borderLipColor)
+	}
+
+	words := strings.Fields(n.message)
+	content := n.icon()
+	if len(words) > 0 {
+		content += " " + words[0]
+	}
+	if len(words) > 1 {
+		content += n.ellipsis
+	}
+
+	// Leave room for the border and padding
+	content = truncate(content, maxWidth-4, n.ellipsis)
+	if n.iconColor != n.textColor {
+		content = styleHead(content, n.icon(), "", iconLipColor, textLipColor)
+	}
+
+	return n.frameStyle(textLipColor, borderLipColor).Render(content)
+}
+
+// badge returns the repetition badge for the alert, or "" if the alert
+// hasn't been repeated.
+func (n *alert) badge() string {
+	if n.count < 2 {
+		return ""
+	}
+	return fmt.Sprintf("•%d", n.count)
+}
+
+// fade returns color blended in from the background by the alert's
+// current animation step, eased by the WithEasing curve when set.
+func (n *alert) fade(color colorful.Color) lipgloss.Color {
+	if n.flashing() {
+		return lipgloss.Color(color.BlendLab(flashColor, DefaultFlashBlend).Hex())
+	}
+	step := n.curLerpStep
+	if n.easing != nil {
+		step = n.easing(step)
+	}
+	return lipgloss.Color(backColor.BlendLab(color, step).Hex())
+}
+
+// flashing reports whether the alert is in the bright phase of a flash.
+func (n *alert) flashing() bool {
+	return n.flashPhases%2 == 1
+}
+
+// styleHead colors the icon that starts the first line of content, along with
+// any badge following it in bold, with iconColor and the rest of the line with
+// textColor. Each segment is colored explicitly so one segment's style reset
+// doesn't strip the color from the next.
+func styleHead(content, icon, badge string, iconColor, textColor lipgloss.Color) string {
+	first, rest, hasRest := strings.Cut(content, "\n")
+	tail := strings.TrimPrefix(first, icon+badge)
+
+	iconStyle := lipgloss.NewStyle().Foreground(iconColor)
+	textStyle := lipgloss.NewStyle().Foreground(textColor)
+	first = iconStyle.Render(icon)
+	if badge != "" {
+		first += iconStyle.Bold(true).Render(badge)
+	}
+	first += textStyle.Render(tail)
+
+	if !hasRest {
+		return first
+	}
+	return first + "\n" + rest
 }
 
 // Region: Model stuff
 
+// AlertKind controls how an alert is framed when rendered.
+type AlertKind int
+
+const (
+	// KindBoxed renders the alert inside a rounded border box. This is the default.
+	KindBoxed AlertKind = iota
+
+	// KindLined renders the alert as text with an accent bar on its left edge.
+	KindLined
+
+	// KindBare renders the alert as plain styled text, with no border.
+	KindBare
+
+	// KindPill renders the alert as a compact single-line badge with rounded
+	// ends, e.g. for a persistent status indicator. In ASCII font mode the
+	// ends are brackets.
+	KindPill
+)
+
+func (k AlertKind) String() string {
+	switch k {
+	case KindBoxed:
+		return "boxed"
+	case KindLined:
+		return "lined"
+	case KindBare:
+		return "bare"
+	case KindPill:
+		return "pill"
+	default:
+		return "unknown"
+	}
+}
+
+// MarshalText encodes the kind by name, e.g. "lined".
+func (k AlertKind) MarshalText() ([]byte, error) {
+	if k.String() == "unknown" {
+		return nil, fmt.Errorf("invalid alert kind %d", int(k))
+	}
+	return []byte(k.String()), nil
+}
+
+// UnmarshalText decodes a kind by name, e.g. "lined".
+func (k *AlertKind) UnmarshalText(text []byte) error {
+	for _, kind := range []AlertKind{KindBoxed, KindLined, KindBare, KindPill} {
+		if kind.String() == string(text) {
+			*k = kind
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid alert kind %q: must be boxed, lined, bare or pill", text)
+}
+
 // AlertDefinition is all the information needed to register a new alert type.
 type AlertDefinition struct {
 	// (Req) Unique key used to refer to an alert type
-	Key string
+	Key string `json:"key"`
 
 	// (Req) Hex code of the color you want your alert to be
-	ForeColor string
+	ForeColor string `json:"foreColor"`
 
 	// (Opt) lipgloss.Style used to render the alert
-	Style lipgloss.Style
+	Style lipgloss.Style `json:"-"`
 
 	// (Opt) String used to prefix the alert message
-	Prefix string
+	Prefix string `json:"prefix,omitempty"`
+
+	// (Opt) Prefix used instead of Prefix with WithUnicodePrefix
+	UnicodePrefix string `json:"unicodePrefix,omitempty"`
+
+	// (Opt) Prefix used instead of Prefix when NerdFont is enabled
+	NerdPrefix string `json:"nerdPrefix,omitempty"`
+
+	// (Opt) Hex code of the border color, if different from ForeColor
+	BorderColor string `json:"borderColor,omitempty"`
+
+	// (Opt) Hex code of the prefix icon's color, if different from ForeColor
+	IconColor string `json:"iconColor,omitempty"`
+
+	// (Opt) Hex code of the message text's color, if different from ForeColor
+	TextColor string `json:"textColor,omitempty"`
+
+	// (Opt) How the alert is framed: boxed (default), lined, bare or pill
+	Kind AlertKind `json:"kind,omitempty"`
+
+	// (Opt) How long alerts of this type display, in seconds. Defaults to the
+	// model's duration.
+	Duration time.Duration `json:"duration,omitempty"`
 
-	// DefaultDur time.Duration
 	// DefaultPos
 	// Default
 }
@@ -216,7 +741,121 @@ type AlertDefinition struct {
 // returned tea.Cmd should be batched into your return.
 func (m AlertModel) NewAlertCmd(alertType, message string) tea.Cmd {
 	return func() tea.Msg {
-		return alertMsg{alertKey: alertType, msg: message, dur: time.Second * m.duration}
+		return alertMsg{alertKey: alertType, msg: message}
+	}
+}
+
+// NewAlertCmdWithID is like NewAlertCmd, but tags the alert with id so it
+// can be targeted by later commands such as SetBadgeCmd.
+func (m AlertModel) NewAlertCmdWithID(id AlertID, alertType, message string) tea.Cmd {
+	return func() tea.Msg {
+		return alertMsg{id: id, alertKey: alertType, msg: message}
+	}
+}
+
+// NewAlertCmdFromSpec is like NewAlertCmd, but takes every detail of the
+// alert from spec.
+func (m AlertModel) NewAlertCmdFromSpec(spec AlertSpec) tea.Cmd {
+	msg := m.specMsg(spec)
+	return func() tea.Msg {
+		return msg
+	}
+}
+
+// specMsg returns the alertMsg that raises the alert described by spec.
+func (m AlertModel) specMsg(spec AlertSpec) alertMsg {
+	return alertMsg{
+		id:       spec.ID,
+		alertKey: spec.Key,
+		msg:      spec.Message,
+		dur:      time.Second * spec.Duration,
+		metadata: spec.Metadata,
+		noWrap:   spec.NoWrap,
+		subtitle: strings.ReplaceAll(spec.Subtitle, "\n", " "),
+		font:     spec.Font,
+		onClick:  spec.OnClick,
+	}
+}
+
+// NewErrorAlertCmd returns the tea.Cmd that raises an Error alert showing
+// err.Error(), or nil when err is nil so it can be returned unconditionally.
+// With WithErrorCause, the root cause of a wrapped error goes on its own line.
+func (m AlertModel) NewErrorAlertCmd(err error) tea.Cmd {
+	if err == nil {
+		return nil
+	}
+
+	message := err.Error()
+	if m.errorCause {
+		message = errorWithCause(err)
+	}
+	return func() tea.Msg {
+		return alertMsg{alertKey: ErrorKey, msg: message}
+	}
+}
+
+// errorWithCause formats err with its innermost wrapped error on a separate
+// "Cause:" line, dropping the cause from the first line where it was
+// appended by wrapping with ": %w".
+func errorWithCause(err error) string {
+	cause := err
+	for next := errors.Unwrap(cause); next != nil; next = errors.Unwrap(cause) {
+		cause = next
+	}
+	if cause == err {
+		return err.Error()
+	}
+
+	head := strings.TrimSuffix(err.Error(), ": "+cause.Error())
+	return head + "\nCause: " + cause.Error()
+}
+
+// NewStatusAlertCmd returns the tea.Cmd that shows a pinned status alert,
+// such as a connection indicator, with the given id and initial message as
+// an Info alert. Status alerts don't time out; move them between states with
+// SetStatusCmd, and they stay until closed or replaced.
+func (m AlertModel) NewStatusAlertCmd(id AlertID, initial string) tea.Cmd {
+	return m.SetStatusCmd(id, InfoKey, initial)
+}
+
+// SetStatusCmd returns the tea.Cmd that switches the status alert with the
+// given id to the alert type key and message, e.g. from "Connecting" to
+// "Connected". The alert changes in place without replaying its fade-in.
+// If the status alert isn't shown, it is shown anew.
+func (m AlertModel) SetStatusCmd(id AlertID, alertType, message string) tea.Cmd {
+	return func() tea.Msg {
+		return statusMsg{id: id, alertKey: alertType, msg: message}
+	}
+}
+
+// NewAlertUntilCmd returns the tea.Cmd that raises an alert which, instead of
+// timing out, stays until a message for which done returns true reaches
+// Update, e.g. keeping "Saving..." up until your SaveDoneMsg arrives. The
+// user can still close it. done is called synchronously from Update for
+// every message while the alert is shown, so it should return quickly.
+func (m AlertModel) NewAlertUntilCmd(alertType, message string, done func(tea.Msg) bool) tea.Cmd {
+	return func() tea.Msg {
+		return alertMsg{alertKey: alertType, msg: message, sticky: true, until: done}
+	}
+}
+
+// AppendToAlertCmd returns the tea.Cmd that appends text as a new line to
+// the message of the active alert with the given id, turning it into a small
+// live log. When a line limit is set, an appended alert shows its latest
+// lines rather than its first. See WithRefreshOnAppend to restart the
+// alert's timer on each append.
+func (m AlertModel) AppendToAlertCmd(id AlertID, text string) tea.Cmd {
+	return func() tea.Msg {
+		return appendMsg{id: id, text: text}
+	}
+}
+
+// SetBadgeCmd returns the tea.Cmd that sets the badge count shown next to
+// the icon of the active alert with the given id. A count below 2 hides
+// the badge. Repeated alerts update the badge automatically.
+func (m AlertModel) SetBadgeCmd(id AlertID, count int) tea.Cmd {
+	return func() tea.Msg {
+		return badgeMsg{id: id, count: count}
 	}
 }
 
@@ -230,6 +869,17 @@ func (m AlertModel) RegisterNewAlertType(definition AlertDefinition) {
 		return
 	}
 
+	for _, optColor := range []string{definition.BorderColor, definition.IconColor, definition.TextColor} {
+		if optColor == "" {
+			continue
+		}
+		_, err = colorful.Hex(optColor)
+		if err != nil {
+			log.Fatal(err)
+			return
+		}
+	}
+
 	if m.alertTypes == nil {
 		m.alertTypes = make(map[string]AlertDefinition)
 	}
@@ -237,62 +887,80 @@ func (m AlertModel) RegisterNewAlertType(definition AlertDefinition) {
 	m.alertTypes[definition.Key] = definition
 }
 
-var unicodePrefixes = map[string]string{
-	InfoKey:  InfoUnicodePrefix,
-	WarnKey:  WarningUnicodePrefix,
-	ErrorKey: ErrorUnicodePrefix,
-	DebugKey: DebugUnicodePrefix,
+// prefixFor returns the prefix of def matching font, or the model's font
+// mode when font is "", falling back to def.Prefix.
+func (m AlertModel) prefixFor(def AlertDefinition, font string) string {
+	if font == "" {
+		font = m.FontMode()
+	}
+	switch {
+	case font == FontNerd && def.NerdPrefix != "":
+		return def.NerdPrefix
+	case font == FontUnicode && def.UnicodePrefix != "":
+		return def.UnicodePrefix
+	default:
+		return def.Prefix
+	}
 }
 
-// Registers all the alert types that ship with BubbleUp by out of the box.
-func (m AlertModel) registerDefaultAlertTypes() {
-	var (
-		infoPref  string
-		warnPref  string
-		errPref   string
-		debugPref string
-	)
-
-	if m.useNerdFont {
-		infoPref = InfoNerdSymbol
-		warnPref = WarnNerdSymbol
-		errPref = ErrorNerdSymbol
-		debugPref = DebugNerdSymbol
-	} else {
-		infoPref = InfoASCIIPrefix
-		warnPref = WarningASCIIPrefix
-		errPref = ErrorASCIIPrefix
-		debugPref = DebugASCIIPrefix
+// durationFor returns how long an alert of type key showing message displays
+// by default, from the type's Duration when set, then WithDurationPerChar
+// when set, or the model's duration otherwise.
+func (m AlertModel) durationFor(key, message string) time.Duration {
+	if def, ok := m.alertTypes[key]; ok && def.Duration > 0 {
+		return time.Second * def.Duration
 	}
+	if m.durationBase <= 0 && m.durationPerRune <= 0 {
+		return time.Second * m.duration
+	}
+
+	d := m.durationBase + m.durationPerRune*time.Duration(ansi.PrintableRuneWidth(message))
+	if m.durationCap > 0 {
+		d = min(d, m.durationCap)
+	}
+	return d
+}
 
+// Registers all the alert types that ship with BubbleUp by out of the box.
+// Each carries its prefix for every font mode, so switching modes with
+// SetFontMode doesn't need them re-registered.
+func (m AlertModel) registerDefaultAlertTypes() {
 	infoDef := AlertDefinition{
-		Key:       InfoKey,
-		Prefix:    infoPref,
-		ForeColor: InfoColor,
+		Key:           InfoKey,
+		Prefix:        InfoASCIIPrefix,
+		UnicodePrefix: InfoUnicodePrefix,
+		NerdPrefix:    InfoNerdSymbol,
+		ForeColor:     InfoColor,
 	}
 
 	m.RegisterNewAlertType(infoDef)
 
 	warnDef := AlertDefinition{
-		Key:       WarnKey,
-		Prefix:    warnPref,
-		ForeColor: WarnColor,
+		Key:           WarnKey,
+		Prefix:        WarningASCIIPrefix,
+		UnicodePrefix: WarningUnicodePrefix,
+		NerdPrefix:    WarnNerdSymbol,
+		ForeColor:     WarnColor,
 	}
 
 	m.RegisterNewAlertType(warnDef)
 
 	errorDef := AlertDefinition{
-		Key:       ErrorKey,
-		Prefix:    errPref,
-		ForeColor: ErrorColor,
+		Key:           ErrorKey,
+		Prefix:        ErrorASCIIPrefix,
+		UnicodePrefix: ErrorUnicodePrefix,
+		NerdPrefix:    ErrorNerdSymbol,
+		ForeColor:     ErrorColor,
 	}
 
 	m.RegisterNewAlertType(errorDef)
 
 	debugDef := AlertDefinition{
-		Key:       DebugKey,
-		Prefix:    debugPref,
-		ForeColor: DebugColor,
+		Key:           DebugKey,
+		Prefix:        DebugASCIIPrefix,
+		UnicodePrefix: DebugUnicodePrefix,
+		NerdPrefix:    DebugNerdSymbol,
+		ForeColor:     DebugColor,
 	}
 
 	m.RegisterNewAlertType(debugDef)
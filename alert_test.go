@@ -0,0 +1,429 @@
+package bubbleup
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/lucasb-eyer/go-colorful"
+	"github.com/muesli/reflow/ansi"
+)
+
+func TestBorderMatchesType(t *testing.T) {
+	m, _ := newTestModel()
+	m.RegisterNewAlertType(AlertDefinition{Key: "custom", ForeColor: "#123456", BorderColor: "#abcdef", Prefix: "*"})
+
+	m = raise(m, "custom", "hi")
+	if got := m.activeAlert.borderColor.Hex(); got != "#abcdef" {
+		t.Errorf("border color without the option = %s, want #abcdef", got)
+	}
+
+	m = m.WithBorderMatchesType()
+	for key, want := range map[string]string{ErrorKey: "#ff0000", InfoKey: "#00ff00", "custom": "#123456"} {
+		m = raise(m, key, "hi "+key)
+		if got := m.activeAlert.borderColor.Hex(); got != want {
+			t.Errorf("%s border color = %s, want %s", key, got, want)
+		}
+	}
+}
+
+func TestRepeatBadge(t *testing.T) {
+	m, _ := newTestModel()
+	m = raise(m, InfoKey, "saved")
+	assertNotContains(t, m.activeAlert.render(), "•")
+
+	m = raise(m, InfoKey, "saved")
+	assertContains(t, m.activeAlert.render(), "(i) •2 saved")
+	m = raise(m, InfoKey, "saved")
+	assertContains(t, m.activeAlert.render(), "(i) •3 saved")
+}
+
+func TestSetBadgeCmd(t *testing.T) {
+	m, _ := newTestModel()
+	m = send(m, m.NewAlertCmdWithID("jobs", InfoKey, "jobs queued")())
+
+	m = send(m, m.SetBadgeCmd("jobs", 7)())
+	assertContains(t, m.activeAlert.render(), "(i) •7 jobs queued")
+	m = send(m, m.SetBadgeCmd("jobs", 0)())
+	assertNotContains(t, m.activeAlert.render(), "•")
+
+	// Other ids are left alone
+	m = send(m, m.SetBadgeCmd("other", 4)())
+	assertNotContains(t, m.activeAlert.render(), "•")
+}
+
+func TestAlertKindsRenderDifferently(t *testing.T) {
+	tests := []struct {
+		kind   AlertKind
+		height int
+		first  string
+	}{
+		{KindBoxed, 3, "╭"},
+		{KindLined, 1, "┃ (i) hi"},
+		{KindBare, 1, " (i) hi"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.kind.String(), func(t *testing.T) {
+			m, _ := newTestModel()
+			def := m.alertTypes[InfoKey]
+			def.Kind = tt.kind
+			m.RegisterNewAlertType(def)
+			m = raise(m, InfoKey, "hi")
+
+			out := m.activeAlert.render()
+			if h := lipgloss.Height(out); h != tt.height {
+				t.Errorf("height = %d, want %d:\n%s", h, tt.height, out)
+			}
+			if !strings.HasPrefix(out, tt.first) {
+				t.Errorf("rendering starts %q, want %q", out, tt.first)
+			}
+
+			// Positioning adapts to the height, e.g. bottom alerts sit on the last line
+			m.activeAlert.position = BottomLeftPosition
+			lines := strings.Split(m.Render(blank(30, 5)), "\n")
+			if !strings.HasPrefix(lines[5-tt.height], tt.first) {
+				t.Errorf("bottom alert doesn't start on line %d:\n%s", 5-tt.height, strings.Join(lines, "\n"))
+			}
+		})
+	}
+}
+
+func TestPillStaysOnOneLine(t *testing.T) {
+	m, _ := newTestModel()
+	def := m.alertTypes[InfoKey]
+	def.Kind = KindPill
+	m.RegisterNewAlertType(def)
+
+	m = raise(m, InfoKey, "deploying\nthe release to every region at once")
+	out := stripANSI(m.activeAlert.render())
+	if h := lipgloss.Height(out); h != 1 {
+		t.Errorf("height = %d, want 1:\n%s", h, out)
+	}
+	if w := lipgloss.Width(out); w > 20 {
+		t.Errorf("width = %d, want at most the alert's 20:\n%s", w, out)
+	}
+	ends := m.pillEnds("")
+	if !strings.HasPrefix(out, ends[0]+" ") || !strings.HasSuffix(out, m.ellipsis+" "+ends[1]) {
+		t.Errorf("pill = %q, want it between %q and %q, cut with an ellipsis", out, ends[0], ends[1])
+	}
+}
+
+func TestAlertKindText(t *testing.T) {
+	for _, kind := range []AlertKind{KindBoxed, KindLined, KindBare, KindPill} {
+		text, err := kind.MarshalText()
+		if err != nil {
+			t.Fatalf("MarshalText(%d): %v", kind, err)
+		}
+		var back AlertKind
+		if err := back.UnmarshalText(text); err != nil || back != kind {
+			t.Errorf("round trip of %q = %v, %v", text, back, err)
+		}
+	}
+	var k AlertKind
+	if err := k.UnmarshalText([]byte("fancy")); err == nil {
+		t.Error("expected an error for an unknown kind")
+	}
+}
+
+func TestIconAndTextColors(t *testing.T) {
+	withTrueColor(t)
+	m, _ := newTestModel()
+	m.RegisterNewAlertType(AlertDefinition{
+		Key: "split", ForeColor: "#0000ff", IconColor: "#ff0000", TextColor: "#00ff00", Prefix: "!",
+	})
+	m.RegisterNewAlertType(AlertDefinition{Key: "single", ForeColor: "#0000ff", Prefix: "!"})
+
+	out := raise(m, "split", "hi").activeAlert.render()
+	assertContains(t, out, "\x1b[38;2;255;0;0m! ")
+	assertContains(t, out, "\x1b[38;2;0;255;0mhi")
+
+	// With neither set, both fall back to the type's color
+	out = raise(m, "single", "hi").activeAlert.render()
+	assertContains(t, out, "\x1b[38;2;0;0;255m")
+	assertNotContains(t, out, "255;0;0")
+	assertNotContains(t, out, "0;255;0")
+}
+
+func TestAppendToAlert(t *testing.T) {
+	m, _ := newTestModel()
+	m = send(m, m.NewAlertCmdWithID("log", InfoKey, "building")())
+	for _, line := range []string{"compiling a rather long package name", "linking", "done"} {
+		m = send(m, m.AppendToAlertCmd("log", line)())
+	}
+
+	out := m.activeAlert.render()
+	assertContains(t, out, "(i) building")
+	assertContains(t, out, "    compiling a")
+	assertContains(t, out, "    rather long")
+	assertContains(t, out, "    done")
+
+	// Other ids are left alone
+	m = send(m, m.AppendToAlertCmd("other", "nope")())
+	assertNotContains(t, m.activeAlert.render(), "nope")
+}
+
+func TestAppendScrollsToLatestLine(t *testing.T) {
+	m, _ := newTestModel()
+	m = m.WithLineLimit(3)
+	m = send(m, m.NewAlertCmdWithID("log", InfoKey, "line 1")())
+	for _, line := range []string{"line 2", "line 3", "line 4", "line 5"} {
+		m = send(m, m.AppendToAlertCmd("log", line)())
+	}
+
+	out := m.activeAlert.render()
+	assertContains(t, out, "(i) … (+3 lines)")
+	assertContains(t, out, "    line 4")
+	assertContains(t, out, "    line 5")
+	assertNotContains(t, out, "line 1")
+}
+
+func TestAppendRefreshesTimer(t *testing.T) {
+	for _, refresh := range []bool{false, true} {
+		m, clock := newTestModel()
+		if refresh {
+			m = m.WithRefreshOnAppend()
+		}
+		m = send(m, m.NewAlertCmdWithID("log", InfoKey, "start")())
+
+		clock.advance(8 * time.Second)
+		m = send(m, m.AppendToAlertCmd("log", "more")())
+		clock.advance(4 * time.Second)
+		if got := m.HasActiveAlert(); got != refresh {
+			t.Errorf("refresh %v: active after 12s = %v, want %v", refresh, got, refresh)
+		}
+	}
+}
+
+func TestStatusAlertMovesThroughStates(t *testing.T) {
+	m, clock := newTestModel()
+	m = send(m, m.NewStatusAlertCmd("conn", "Connecting")())
+	born := m.activeAlert.birthTime
+
+	states := []struct{ key, message string }{
+		{InfoKey, "Connected"},
+		{ErrorKey, "Connection lost"},
+		{WarnKey, "Reconnecting"},
+	}
+	for _, state := range states {
+		clock.advance(time.Minute)
+		m = send(m, m.SetStatusCmd("conn", state.key, state.message)())
+		if !m.HasVisibleAlertOfType(state.key) || m.activeAlert.message != state.message {
+			t.Fatalf("expected %s %q to show", state.key, state.message)
+		}
+		if m.activeAlert.id != "conn" || !m.activeAlert.birthTime.Equal(born) {
+			t.Errorf("%s: expected the same alert to change in place", state.message)
+		}
+		want := m.prefixFor(m.alertTypes[state.key], "")
+		assertContains(t, m.activeAlert.render(), want+" "+strings.Fields(state.message)[0])
+	}
+
+	// Status alerts don't time out
+	clock.advance(time.Hour)
+	if m = send(m, struct{}{}); !m.HasActiveAlert() {
+		t.Error("expected the status alert to stay up")
+	}
+}
+
+func TestNoWrapKeepsPreformattedLines(t *testing.T) {
+	m, _ := newTestModel()
+	m = send(m, m.NewAlertCmdFromSpec(AlertSpec{Key: InfoKey, Message: "name  size\nmain.go  12", NoWrap: true})())
+
+	lines := strings.Split(stripANSI(m.activeAlert.render()), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("expected two message lines in the box, got:\n%s", strings.Join(lines, "\n"))
+	}
+	assertContains(t, lines[1], "(i) name  size ")
+	assertContains(t, lines[2], "    main.go  12 ")
+
+	// The box fits the widest line rather than the full width
+	if w := lipgloss.Width(lines[0]); w >= 22 {
+		t.Errorf("box is %d wide, want it sized to the widest line", w)
+	}
+
+	// Lines too wide for the alert are cut rather than wrapped
+	m = send(m, m.NewAlertCmdFromSpec(AlertSpec{Key: InfoKey, Message: "a line far too long to fit", NoWrap: true})())
+	if lines := strings.Split(stripANSI(m.activeAlert.render()), "\n"); len(lines) != 3 || !strings.Contains(lines[1], "…") {
+		t.Errorf("expected one cut line, got:\n%s", strings.Join(lines, "\n"))
+	}
+}
+
+func TestNewlinesAreHardBreaksBeforeWrapping(t *testing.T) {
+	m, _ := newTestModel()
+	m = raise(m, InfoKey, "first line\nsecond line is long enough to wrap")
+
+	lines := strings.Split(stripANSI(m.activeAlert.render()), "\n")
+	want := []string{"(i) first line", "    second line is", "    long enough to", "    wrap"}
+	if len(lines) != len(want)+2 {
+		t.Fatalf("got %d lines, want %d:\n%s", len(lines), len(want)+2, strings.Join(lines, "\n"))
+	}
+	for i, line := range want {
+		assertContains(t, lines[i+1], "│ "+line+" ")
+	}
+}
+
+func TestASCIIBorderUsesASCIICharacters(t *testing.T) {
+	m, _ := newTestModel()
+	m = raise(m.WithBorder(ASCIIBorder), InfoKey, "plain")
+
+	out := stripANSI(m.activeAlert.render())
+	want := "+" + strings.Repeat("-", 20) + "+\n" +
+		"| (i) plain          |\n" +
+		"+" + strings.Repeat("-", 20) + "+"
+	if out != want {
+		t.Errorf("got:\n%s\nwant:\n%s", out, want)
+	}
+}
+
+func TestErrorAlertFromWrappedError(t *testing.T) {
+	m, _ := newTestModel()
+	m = m.WithWidth(40)
+	err := fmt.Errorf("save config: %w", fmt.Errorf("open file: %w", os.ErrPermission))
+
+	plain := send(m, m.NewErrorAlertCmd(err)())
+	if plain.activeAlert.key != ErrorKey || plain.activeAlert.message != err.Error() {
+		t.Errorf("alert = %s %q, want an Error alert of err.Error()", plain.activeAlert.key, plain.activeAlert.message)
+	}
+
+	m = m.WithErrorCause()
+	caused := send(m, m.NewErrorAlertCmd(err)())
+	if got, want := caused.activeAlert.message, "save config: open file\nCause: permission denied"; got != want {
+		t.Errorf("message = %q, want %q", got, want)
+	}
+	out := stripANSI(caused.activeAlert.render())
+	assertContains(t, out, "[!!] save config: open file ")
+	assertContains(t, out, "     Cause: permission denied ")
+
+	if m.NewErrorAlertCmd(nil) != nil {
+		t.Error("expected no command for a nil error")
+	}
+}
+
+func TestDynamicWidthFitsMessageExactly(t *testing.T) {
+	m, _ := newTestModel()
+	m = m.WithWidth(40).WithMinWidth(5)
+
+	for _, tt := range []struct{ message, want string }{
+		{"exactly fits", "│ (i) exactly fits │"},
+		{"short\nsecond line", "│     second line │"},
+	} {
+		lines := strings.Split(stripANSI(raise(m, InfoKey, tt.message).activeAlert.render()), "\n")
+		if len(lines) != strings.Count(tt.message, "\n")+3 {
+			t.Errorf("%q wrapped:\n%s", tt.message, strings.Join(lines, "\n"))
+			continue
+		}
+		assertContains(t, strings.Join(lines, "\n"), tt.want)
+	}
+
+	// The repeat badge is measured too
+	repeated := raise(raise(m, InfoKey, "again"), InfoKey, "again")
+	if lines := strings.Split(stripANSI(repeated.activeAlert.render()), "\n"); len(lines) != 3 {
+		t.Errorf("badged alert wrapped:\n%s", strings.Join(lines, "\n"))
+	}
+}
+
+func TestRenderCacheInvalidatesOnChange(t *testing.T) {
+	m, _ := newTestModel()
+	m = raise(m, InfoKey, "cached")
+	n := m.activeAlert
+
+	first := n.render()
+	if n.render() != first || n.rendered != first {
+		t.Fatal("expected an unchanged alert to reuse its rendering")
+	}
+	n.count = 3
+	if again := n.render(); again == first || !strings.Contains(stripANSI(again), "3") {
+		t.Errorf("expected the badge change to re-render, got:\n%s", again)
+	}
+}
+
+// benchmarkAlert returns a settled alert with a message that wraps.
+func benchmarkAlert() *alert {
+	m, _ := newTestModel()
+	m = m.WithWidth(40)
+	return raise(m, WarnKey, "build finished with warnings in three packages, see the log").activeAlert
+}
+
+func BenchmarkAlertRenderCached(b *testing.B) {
+	n := benchmarkAlert()
+	b.ReportAllocs()
+	for b.Loop() {
+		n.render()
+	}
+}
+
+func BenchmarkAlertRenderUncached(b *testing.B) {
+	n := benchmarkAlert()
+	b.ReportAllocs()
+	for b.Loop() {
+		n.rendered = ""
+		n.render()
+	}
+}
+
+func TestPerAlertFontOverride(t *testing.T) {
+	m, _ := newTestModel()
+	m = m.SetFontMode(FontUnicode)
+
+	ascii := send(m, m.NewAlertCmdFromSpec(AlertSpec{Key: InfoKey, Message: "to a log pane", Font: FontASCII})())
+	assertContains(t, stripANSI(ascii.activeAlert.render()), InfoASCIIPrefix+" to a log pane")
+	if ascii.FontMode() != FontUnicode {
+		t.Errorf("FontMode() = %q, want the model to stay %q", ascii.FontMode(), FontUnicode)
+	}
+
+	plain := raise(ascii, InfoKey, "back to normal")
+	assertContains(t, stripANSI(plain.activeAlert.render()), strings.TrimSpace(InfoUnicodePrefix)+" ")
+	assertNotContains(t, stripANSI(plain.activeAlert.render()), InfoASCIIPrefix)
+}
+
+func TestStyledMessageBoxKeepsWidth(t *testing.T) {
+	withTrueColor(t)
+	m, _ := newTestModel()
+	bold := lipgloss.NewStyle().Bold(true).Underline(true).Foreground(lipgloss.Color("#FF00FF"))
+	message := bold.Render("🎉 shipped") + " to " + "\x1b[3;38;5;208mevery\x1b[0m région 日本"
+	m = raise(m, InfoKey, message)
+
+	for i, line := range strings.Split(m.activeAlert.render(), "\n") {
+		if w := ansi.PrintableRuneWidth(line); w != 22 {
+			t.Errorf("line %d is %d wide, want 22: %q", i, w, line)
+		}
+	}
+}
+
+func TestFitLinesPadsAndCuts(t *testing.T) {
+	got := strings.Split(stripANSI(fitLines("ab\n\x1b[1mabcdef\x1b[0m\n日本語", 4)), "\n")
+	for i, want := range []string{"ab  ", "abcd", "日本"} {
+		if got[i] != want {
+			t.Errorf("line %d = %q, want %q", i, got[i], want)
+		}
+	}
+}
+
+func TestEasingShapesFadeIn(t *testing.T) {
+	m, _ := newTestModel()
+	color, _ := colorful.Hex("#ff8800")
+	fadeAt := func(m AlertModel, step float64) lipgloss.Color {
+		n := raise(m, InfoKey, "fading").activeAlert
+		n.curLerpStep = step
+		return n.fade(color)
+	}
+
+	for _, ease := range []func(float64) float64{EaseOut, EaseInOut} {
+		eased := m.WithEasing(ease)
+		for _, step := range []float64{0, 1} {
+			if got, want := fadeAt(eased, step), fadeAt(m, step); got != want {
+				t.Errorf("eased color at %v = %s, want %s as with linear", step, got, want)
+			}
+		}
+		if fadeAt(eased, 0.3) == fadeAt(m, 0.3) {
+			t.Errorf("eased color at 0.3 = %s, want it to differ from linear", fadeAt(m, 0.3))
+		}
+	}
+
+	// Easing out blends in further than linear early on
+	if got, want := fadeAt(m.WithEasing(EaseOut), 0.3), fadeAt(m, 0.51); got != want {
+		t.Errorf("EaseOut color at 0.3 = %s, want %s as linear at 0.51", got, want)
+	}
+}
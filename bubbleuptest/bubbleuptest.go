@@ -0,0 +1,142 @@
+// Package bubbleuptest provides helpers for testing BubbleUp alerts without a
+// running BubbleTea program or real timers.
+//
+// A Driver owns an AlertModel wired to a manually advanced Clock. Messages are
+// fed through Update, and any commands the model returns are only run when
+// time is advanced, so alert lifetimes can be asserted deterministically:
+//
+//	func TestSavedAlertTimesOut(t *testing.T) {
+//		d := bubbleuptest.NewDriver(*bubbleup.NewAlertModel(50, false, 2))
+//
+//		d.Alert(bubbleup.InfoKey, "File saved")
+//		d.AssertActive(t)
+//
+//		d.Advance(3 * time.Second)
+//		d.AssertNoAlert(t)
+//	}
+//
+// The Driver pairs well with charmbracelet/x/teatest: drive the alert model
+// here and assert the rendered output of your own model there.
+package bubbleuptest
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/dzannotti/bubbleup"
+)
+
+// Clock is a bubbleup.Clock that only moves when Advance is called.
+// Ticks it schedules fire immediately with the clock's current time
+// whenever they are run.
+type Clock struct {
+	now time.Time
+}
+
+// NewClock returns a Clock starting at start.
+func NewClock(start time.Time) *Clock {
+	return &Clock{now: start}
+}
+
+// Now returns the clock's current time.
+func (c *Clock) Now() time.Time {
+	return c.now
+}
+
+// Tick returns a command producing fn's message at the clock's time when run.
+func (c *Clock) Tick(_ time.Duration, fn func(time.Time) tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		return fn(c.now)
+	}
+}
+
+// Advance moves the clock forward by d.
+func (c *Clock) Advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+// Driver feeds messages to an AlertModel and runs the commands it returns
+// as time is advanced.
+type Driver struct {
+	Model   bubbleup.AlertModel
+	Clock   *Clock
+	pending []tea.Cmd
+}
+
+// NewDriver returns a Driver for model, replacing its clock with a Clock
+// starting at the Unix epoch.
+func NewDriver(model bubbleup.AlertModel) *Driver {
+	clock := NewClock(time.Unix(0, 0))
+	return &Driver{
+		Model: model.WithClock(clock),
+		Clock: clock,
+	}
+}
+
+// Send passes msg to the model's Update and queues the returned command.
+func (d *Driver) Send(msg tea.Msg) {
+	out, cmd := d.Model.Update(msg)
+	d.Model = out.(bubbleup.AlertModel)
+	if cmd != nil {
+		d.pending = append(d.pending, cmd)
+	}
+}
+
+// Alert raises an alert of the given type, as NewAlertCmd would once run.
+func (d *Driver) Alert(alertType, message string) {
+	d.Send(d.Model.NewAlertCmd(alertType, message)())
+}
+
+// Advance moves time forward by dur in steps of bubbleup.DefaultTickInterval,
+// running pending commands after each step as a BubbleTea program would.
+func (d *Driver) Advance(dur time.Duration) {
+	for dur > 0 {
+		step := min(dur, bubbleup.DefaultTickInterval)
+		d.Clock.Advance(step)
+		d.Flush()
+		dur -= step
+	}
+}
+
+// Flush runs all pending commands once, sending their messages to the model.
+// Commands returned while flushing are queued for the next flush.
+func (d *Driver) Flush() {
+	cmds := d.pending
+	d.pending = nil
+	for len(cmds) > 0 {
+		cmd := cmds[0]
+		cmds = cmds[1:]
+		if cmd == nil {
+			continue
+		}
+		switch msg := cmd().(type) {
+		case nil:
+		case tea.BatchMsg:
+			cmds = append(cmds, msg...)
+		default:
+			d.Send(msg)
+		}
+	}
+}
+
+// Render overlays the model's active alert onto content.
+func (d *Driver) Render(content string) string {
+	return d.Model.Render(content)
+}
+
+// AssertActive fails the test if no alert is active.
+func (d *Driver) AssertActive(t testing.TB) {
+	t.Helper()
+	if !d.Model.HasActiveAlert() {
+		t.Errorf("expected an active alert, got none")
+	}
+}
+
+// AssertNoAlert fails the test if an alert is active.
+func (d *Driver) AssertNoAlert(t testing.TB) {
+	t.Helper()
+	if d.Model.HasActiveAlert() {
+		t.Errorf("expected no active alert, got one")
+	}
+}
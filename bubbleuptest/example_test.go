@@ -0,0 +1,98 @@
+package bubbleuptest_test
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/exp/teatest"
+	"github.com/dzannotti/bubbleup"
+	"github.com/dzannotti/bubbleup/bubbleuptest"
+)
+
+func ExampleDriver() {
+	d := bubbleuptest.NewDriver(*bubbleup.NewAlertModel(50, false, 2))
+
+	d.Alert(bubbleup.InfoKey, "File saved")
+	fmt.Println("after alert:", d.Model.HasActiveAlert())
+
+	d.Advance(3 * time.Second)
+	fmt.Println("after 3s:", d.Model.HasActiveAlert())
+	// Output:
+	// after alert: true
+	// after 3s: false
+}
+
+func TestAlertTimesOut(t *testing.T) {
+	d := bubbleuptest.NewDriver(*bubbleup.NewAlertModel(50, false, 2))
+
+	d.Alert(bubbleup.InfoKey, "File saved")
+	d.AssertActive(t)
+
+	d.Advance(1 * time.Second)
+	d.AssertActive(t)
+
+	d.Advance(2 * time.Second)
+	d.AssertNoAlert(t)
+}
+
+// app is a minimal program embedding the alert model, raising an alert
+// when "s" is pressed.
+type app struct {
+	alert bubbleup.AlertModel
+	clock *bubbleuptest.Clock
+}
+
+// advanceMsg moves the app's clock forward from inside the program, so the
+// clock is only ever touched by the program's own goroutine.
+type advanceMsg time.Duration
+
+func (a app) Init() tea.Cmd {
+	return a.alert.Init()
+}
+
+func (a app) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "s":
+			return a, a.alert.NewAlertCmd(bubbleup.InfoKey, "File saved")
+		case "q":
+			return a, tea.Quit
+		}
+	case advanceMsg:
+		a.clock.Advance(time.Duration(msg))
+	}
+	out, cmd := a.alert.Update(msg)
+	a.alert = out.(bubbleup.AlertModel)
+	return a, cmd
+}
+
+func (a app) View() string {
+	return a.alert.Render("main view\n\n\n\n")
+}
+
+// TestAlertTimesOutWithTeatest runs the alert model inside a real program
+// with teatest, as an alternative to the Driver for end-to-end checks of an
+// app's own view. Test mode and a Clock keep it off the wall clock.
+func TestAlertTimesOutWithTeatest(t *testing.T) {
+	clock := bubbleuptest.NewClock(time.Unix(0, 0))
+	alert := bubbleup.NewAlertModel(30, false, 1).WithTestMode().WithClock(clock)
+	tm := teatest.NewTestModel(t, app{alert: alert, clock: clock},
+		teatest.WithInitialTermSize(40, 5))
+
+	tm.Send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	teatest.WaitFor(t, tm.Output(), func(out []byte) bool {
+		return bytes.Contains(out, []byte("File saved"))
+	}, teatest.WithDuration(time.Second))
+
+	// The alert lasts a second, so it's gone two seconds on
+	tm.Send(advanceMsg(2 * time.Second))
+	tm.Send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")})
+	final := tm.FinalModel(t, teatest.WithFinalTimeout(3*time.Second)).(app)
+	if final.alert.HasActiveAlert() {
+		t.Error("expected the alert to have timed out")
+	}
+}
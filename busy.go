@@ -0,0 +1,58 @@
+package bubbleup
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// Spinner frames shown in place of a busy alert's icon, one per tick
+var (
+	unicodeSpinner = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+	asciiSpinner   = []string{"|", "/", "-", "\\"}
+)
+
+// busyMsg is the tea.Msg used to mark an alert as busy or not
+type busyMsg struct {
+	id   AlertID
+	busy bool
+}
+
+// SetAlertBusyCmd returns the tea.Cmd that swaps the icon of the active alert
+// with the given id for a spinner while busy, e.g. while "Retrying…", and
+// brings the icon back once busy is false. The spinner turns with the
+// alert's ticks, so it stays still in test mode and while paused.
+func (m AlertModel) SetAlertBusyCmd(id AlertID, busy bool) tea.Cmd {
+	return func() tea.Msg {
+		return busyMsg{id: id, busy: busy}
+	}
+}
+
+// setBusy starts or stops the spinner of the active alert if msg targets it.
+func (m *AlertModel) setBusy(msg busyMsg) {
+	n := m.activeAlert
+	if n == nil || msg.id == "" || n.id != msg.id || (n.spinner != nil) == msg.busy {
+		return
+	}
+	n.spinner, n.spinFrame = nil, 0
+	if msg.busy {
+		n.spinner = m.spinnerFor(n.font)
+	}
+	m.publishActive(AlertUpdated)
+}
+
+// spinnerFor returns the spinner frames for font, or the model's font mode
+// when font is "". ASCII gets a plain line spinner.
+func (m AlertModel) spinnerFor(font string) []string {
+	if font == "" {
+		font = m.FontMode()
+	}
+	if font == FontASCII {
+		return asciiSpinner
+	}
+	return unicodeSpinner
+}
+
+// icon returns the alert's prefix, or the current spinner frame while busy.
+func (n *alert) icon() string {
+	if n.spinner == nil {
+		return n.prefix
+	}
+	return n.spinner[n.spinFrame%len(n.spinner)]
+}
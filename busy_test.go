@@ -0,0 +1,39 @@
+package bubbleup
+
+import "testing"
+
+func TestBusyAlertSpinsInPlaceOfIcon(t *testing.T) {
+	m, _ := newTestModel()
+	m = send(m, m.NewAlertCmdWithID("sync", WarnKey, "Retrying…")())
+	n := m.activeAlert
+	icon, idle := n.icon(), m.Fingerprint()
+
+	// Other ids are left alone
+	if m = send(m, m.SetAlertBusyCmd("other", true)()); n.spinner != nil {
+		t.Fatal("expected only the targeted alert to spin")
+	}
+
+	m = send(m, m.SetAlertBusyCmd("sync", true)())
+	spinner := m.spinnerFor("")
+	seen := map[uint64]bool{idle: true}
+	for frame := range 3 {
+		if got := n.icon(); got != spinner[frame] {
+			t.Errorf("icon at frame %d = %q, want %q", frame, got, spinner[frame])
+		}
+		assertContains(t, stripANSI(n.render()), spinner[frame]+" Retrying…")
+		fp := m.Fingerprint()
+		if seen[fp] {
+			t.Errorf("fingerprint at frame %d repeats an earlier one", frame)
+		}
+		seen[fp] = true
+		n.spinFrame++
+	}
+
+	m = send(m, m.SetAlertBusyCmd("sync", false)())
+	if got := n.icon(); got != icon {
+		t.Errorf("icon once idle = %q, want %q back", got, icon)
+	}
+	if m.Fingerprint() != idle {
+		t.Error("expected the fingerprint to match the idle alert again")
+	}
+}
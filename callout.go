@@ -0,0 +1,71 @@
+package bubbleup
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/muesli/reflow/ansi"
+)
+
+// Point is a cell in the content passed to Render, counted in columns from
+// the left and lines from the top, both starting at 0.
+type Point struct {
+	X, Y int
+}
+
+// Pointer glyphs, indexed by direction as [dy+1][dx+1].
+var (
+	unicodePointers = [3][3]string{{"↖", "↑", "↗"}, {"←", "", "→"}, {"↙", "↓", "↘"}}
+	asciiPointers   = [3][3]string{{"\\", "^", "/"}, {"<", "", ">"}, {"/", "v", "\\"}}
+)
+
+// NewCalloutAlertCmd returns the tea.Cmd that raises an info alert with a
+// small arrow next to its box pointing at target, e.g. a menu item in a
+// tutorial. The arrow is left out while target lies under the box.
+func (m AlertModel) NewCalloutAlertCmd(message string, target Point) tea.Cmd {
+	return func() tea.Msg {
+		return alertMsg{alertKey: InfoKey, msg: message, target: &target}
+	}
+}
+
+// drawPointer draws the active alert's pointer into lines, just outside the
+// box whose top-left cell is at (left, top), on the side facing the target.
+func (m AlertModel) drawPointer(lines []string, left, top, width, height int) {
+	target := m.activeAlert.target
+	if target == nil {
+		return
+	}
+
+	x := min(max(target.X, left-1), left+width)
+	y := min(max(target.Y, top-1), top+height)
+	dx, dy := direction(x, left, left+width), direction(y, top, top+height)
+	if dx == 0 && dy == 0 {
+		// Target is under the box
+		return
+	}
+	if x < 0 || y < 0 || y >= len(lines) {
+		return
+	}
+
+	glyphs := asciiPointers
+	if m.useNerdFont || m.useUnicodePrefix {
+		glyphs = unicodePointers
+	}
+
+	line := lines[y]
+	var rest string
+	if ansi.PrintableRuneWidth(line) > x+1 {
+		rest = cutLeft(line, x+1)
+	}
+	lines[y] = padRight(cutRight(line, x), x) + glyphs[dy+1][dx+1] + rest
+}
+
+// direction returns -1, 0 or 1 as v lies before, within or after [lo, hi).
+func direction(v, lo, hi int) int {
+	switch {
+	case v < lo:
+		return -1
+	case v >= hi:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,39 @@
+package bubbleup
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCalloutPointsTowardTarget(t *testing.T) {
+	// The box covers columns 0-21 and lines 0-2 at the top left
+	for _, tt := range []struct {
+		target Point
+		at     Point
+		glyph  string
+	}{
+		{Point{30, 1}, Point{22, 1}, ">"},
+		{Point{5, 8}, Point{5, 3}, "v"},
+		{Point{35, 9}, Point{22, 3}, "\\"},
+	} {
+		m, _ := newTestModel()
+		m = m.WithPosition(TopLeftPosition)
+		m = send(m, m.NewCalloutAlertCmd("look", tt.target)())
+
+		lines := strings.Split(stripANSI(m.Render(blank(40, 10))), "\n")
+		if got := string([]rune(lines[tt.at.Y])[tt.at.X]); got != tt.glyph {
+			t.Errorf("target %v: %q at %v, want %q", tt.target, got, tt.at, tt.glyph)
+		}
+	}
+}
+
+func TestCalloutHidesPointerUnderBox(t *testing.T) {
+	m, _ := newTestModel()
+	m = m.WithPosition(TopLeftPosition)
+	m = send(m, m.NewCalloutAlertCmd("look", Point{3, 1})())
+
+	plain := raise(m, InfoKey, "look")
+	if got, want := m.Render(blank(40, 10)), plain.Render(blank(40, 10)); got != want {
+		t.Errorf("expected no pointer for a target under the box, got:\n%s", got)
+	}
+}
@@ -0,0 +1,23 @@
+package bubbleup
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// updateClick handles a left click on the active alert, returning its
+// AlertSpec.OnClick command, or dismissing it when ctrl is held. Bounds are
+// worked out as for WithPauseOnHover.
+func (m *AlertModel) updateClick(msg tea.MouseMsg) tea.Cmd {
+	if !m.shown() || msg.Action != tea.MouseActionPress || msg.Button != tea.MouseButtonLeft {
+		return nil
+	}
+	if !m.alertContains(msg.X, msg.Y) {
+		return nil
+	}
+
+	n := m.activeAlert
+	if msg.Ctrl {
+		m.notifyDismiss(DismissClosed)
+		m.activeAlert = nil
+		return m.advanceSequence(n)
+	}
+	return n.onClick
+}
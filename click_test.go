@@ -0,0 +1,75 @@
+package bubbleup
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type clickedMsg struct{}
+
+// clickAt returns a left click on the cell (x, y).
+func clickAt(x, y int) tea.MouseMsg {
+	return tea.MouseMsg{X: x, Y: y, Action: tea.MouseActionPress, Button: tea.MouseButtonLeft}
+}
+
+// click sends msg to m, returning the model and the messages from its command.
+func click(m AlertModel, msg tea.MouseMsg) (AlertModel, []tea.Msg) {
+	out, cmd := m.Update(msg)
+	return out.(AlertModel), runCmd(cmd)
+}
+
+// clickModel returns a 50x5 model showing an alert with an OnClick command
+// at the top left.
+func clickModel() AlertModel {
+	m, _ := newTestModel()
+	m = m.WithPosition(TopLeftPosition)
+	m = send(m, tea.WindowSizeMsg{Width: 50, Height: 5})
+	return m
+}
+
+func raiseClickable(m AlertModel) AlertModel {
+	spec := AlertSpec{Key: InfoKey, Message: "click me", OnClick: func() tea.Msg { return clickedMsg{} }}
+	return send(m, m.NewAlertCmdFromSpec(spec)())
+}
+
+func TestClickRunsOnClickWithinBounds(t *testing.T) {
+	m := raiseClickable(clickModel())
+
+	if _, msgs := click(m, clickAt(40, 4)); len(msgs) != 0 {
+		t.Errorf("click outside the alert returned %v, want nothing", msgs)
+	}
+	m, msgs := click(m, clickAt(2, 1))
+	if len(msgs) != 1 || msgs[0] != (clickedMsg{}) {
+		t.Errorf("click on the alert returned %v, want the OnClick message", msgs)
+	}
+	if m.activeAlert == nil {
+		t.Error("expected a plain click to keep the alert")
+	}
+
+	ctrl := clickAt(2, 1)
+	ctrl.Ctrl = true
+	if m, _ = click(m, ctrl); m.activeAlert != nil {
+		t.Error("expected ctrl+click to dismiss the alert")
+	}
+}
+
+func TestClickBoundsFollowSidebar(t *testing.T) {
+	m := clickModel().WithSidebar(24, lipgloss.Right)
+	m = raiseClickable(m)
+
+	// Where the alert would be without the sidebar is now content
+	if _, msgs := click(m, clickAt(2, 1)); len(msgs) != 0 {
+		t.Errorf("click in the content returned %v, want nothing", msgs)
+	}
+	if _, msgs := click(m, clickAt(28, 1)); len(msgs) != 1 {
+		t.Errorf("click on the alert in the gutter returned %v, want the OnClick message", msgs)
+	}
+
+	// Hovering uses the same bounds
+	m = m.WithPauseOnHover()
+	if m = send(m, mouseAt(28, 1)); m.activeAlert.hoveredAt.IsZero() {
+		t.Error("expected hovering the alert in the gutter to pause it")
+	}
+}
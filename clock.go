@@ -0,0 +1,45 @@
+package bubbleup
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Clock supplies the time used to schedule and expire alerts. The default
+// clock uses the system time and tea.Tick; supply your own via WithClock to
+// drive alert lifetimes deterministically, for example in tests.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+
+	// Tick returns a tea.Cmd that produces fn's message once d has elapsed.
+	Tick(d time.Duration, fn func(time.Time) tea.Msg) tea.Cmd
+}
+
+// systemClock is the Clock backed by the real system time.
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) Tick(d time.Duration, fn func(time.Time) tea.Msg) tea.Cmd {
+	return tea.Tick(d, fn)
+}
+
+// WithClock returns a new AlertModel that uses clock for all timing.
+// This is an immutable operation.
+func (m AlertModel) WithClock(clock Clock) AlertModel {
+	m.clock = clock
+	return m
+}
+
+// getClock returns the model's clock, falling back to the system clock
+// for models that weren't created via NewAlertModel.
+func (m AlertModel) getClock() Clock {
+	if m.clock == nil {
+		return systemClock{}
+	}
+	return m.clock
+}
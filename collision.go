@@ -0,0 +1,47 @@
+package bubbleup
+
+// IDCollisionPolicy controls what happens when an alert is raised with the
+// same explicit ID as the active alert, e.g. via NewAlertCmdWithID.
+type IDCollisionPolicy int
+
+const (
+	// IDCollisionUpsert updates the active alert in place with the new type,
+	// message and duration, without replaying its fade-in.
+	IDCollisionUpsert IDCollisionPolicy = iota
+
+	// IDCollisionReject discards the new alert and keeps the active one. The
+	// rejected alert is reported to the OnDismiss hook with
+	// DismissRejectedDuplicateID.
+	IDCollisionReject
+)
+
+// WithIDCollisionPolicy returns a new AlertModel that handles alerts sharing
+// the active alert's explicit ID according to policy. Defaults to
+// IDCollisionUpsert. Alerts without an ID are unaffected.
+// This is an immutable operation.
+func (m AlertModel) WithIDCollisionPolicy(policy IDCollisionPolicy) AlertModel {
+	m.idCollision = policy
+	return m
+}
+
+// collides reports whether msg carries the same explicit ID as the active
+// alert.
+func (m AlertModel) collides(msg alertMsg) bool {
+	return msg.id != "" && m.activeAlert != nil && m.activeAlert.id == msg.id
+}
+
+// upsert replaces the active alert with one built from msg, carrying on from
+// its current frame so the change doesn't flicker.
+func (m *AlertModel) upsert(msg alertMsg) {
+	next := m.newAlert(msg)
+	if next == nil {
+		return
+	}
+	next.curLerpStep = m.activeAlert.curLerpStep
+	next.birthTime = m.activeAlert.birthTime
+	if m.timerMode == TimerAbsolute {
+		next.deathTime = m.activeAlert.deathTime
+	}
+	m.activeAlert = next
+	m.publishActive(AlertUpdated)
+}
@@ -0,0 +1,36 @@
+package bubbleup
+
+import "testing"
+
+func TestIDCollisionUpsertUpdatesActiveAlert(t *testing.T) {
+	m, _ := newTestModel()
+	m, got := recordDismissals(m)
+	m = send(m, m.NewAlertCmdWithID("job", InfoKey, "running")())
+	m = send(m, m.NewAlertCmdWithID("job", ErrorKey, "failed")())
+
+	if m.activeAlert.key != ErrorKey || m.activeAlert.message != "failed" {
+		t.Errorf("active alert = %s %q, want the upserted error", m.activeAlert.key, m.activeAlert.message)
+	}
+	if len(*got) != 0 {
+		t.Errorf("expected no dismissals on upsert, got %v", *got)
+	}
+}
+
+func TestIDCollisionRejectKeepsActiveAlert(t *testing.T) {
+	m, _ := newTestModel()
+	m, got := recordDismissals(m.WithIDCollisionPolicy(IDCollisionReject))
+	m = send(m, m.NewAlertCmdWithID("job", InfoKey, "running")())
+	m = send(m, m.NewAlertCmdWithID("job", ErrorKey, "failed")())
+
+	if m.activeAlert.message != "running" {
+		t.Errorf("active alert = %q, want the original kept", m.activeAlert.message)
+	}
+	if len(*got) != 1 || (*got)[0].reason != DismissRejectedDuplicateID || (*got)[0].spec.Message != "failed" {
+		t.Errorf("dismissals = %v, want the rejected alert reported", *got)
+	}
+
+	// A different ID still replaces the active alert
+	if m = send(m, m.NewAlertCmdWithID("other", ErrorKey, "failed")()); m.activeAlert.message != "failed" {
+		t.Errorf("active alert = %q, want a new ID to replace it", m.activeAlert.message)
+	}
+}
@@ -0,0 +1,143 @@
+package bubbleup
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Font modes accepted by Config.Font and SetFontMode.
+const (
+	FontNerd    = "nerdfont"
+	FontUnicode = "unicode"
+	FontASCII   = "ascii"
+)
+
+// Config declaratively describes an AlertModel, e.g. when loaded from a JSON
+// config file. Pass it to NewAlertModelFromConfig to build the model.
+type Config struct {
+	// (Req) Maximum width of alerts, or their fixed width when MinWidth is 0
+	Width int `json:"width"`
+
+	// (Opt) Minimum width of alerts, enabling dynamic width. See WithMinWidth.
+	MinWidth int `json:"minWidth,omitempty"`
+
+	// (Opt) Width at which message text wraps. See WithTextWidth.
+	TextWidth int `json:"textWidth,omitempty"`
+
+	// (Req) How long alerts display before auto-dismissing, in seconds
+	Duration time.Duration `json:"duration"`
+
+	// (Opt) Where alerts appear, either as a Position value ("TR") or its
+	// String form ("top-right"). Defaults to DefaultPosition().
+	Position string `json:"position,omitempty"`
+
+	// (Opt) Prefix symbols for the included alert types: FontNerd,
+	// FontUnicode or FontASCII. Defaults to FontASCII.
+	Font string `json:"font,omitempty"`
+
+	// (Opt) Whether esc closes the active alert. See WithAllowEscToClose.
+	AllowEscToClose bool `json:"allowEscToClose,omitempty"`
+
+	// (Opt) Maximum wrapped lines per alert. See WithLineLimit.
+	LineLimit int `json:"lineLimit,omitempty"`
+
+	// (Opt) Marker for truncated text. See WithEllipsis.
+	Ellipsis string `json:"ellipsis,omitempty"`
+
+	// (Opt) Custom alert types, or overrides of the included ones
+	AlertTypes []AlertDefinition `json:"alertTypes,omitempty"`
+}
+
+// Validate reports every problem with the config, or nil if it is usable.
+func (c Config) Validate() error {
+	var errs []error
+
+	if c.Width <= 0 {
+		errs = append(errs, fmt.Errorf("invalid width %d: must be positive", c.Width))
+	}
+	if c.MinWidth < 0 {
+		errs = append(errs, fmt.Errorf("invalid minWidth %d: must not be negative", c.MinWidth))
+	}
+	if c.TextWidth < 0 {
+		errs = append(errs, fmt.Errorf("invalid textWidth %d: must not be negative", c.TextWidth))
+	}
+	if c.Duration <= 0 {
+		errs = append(errs, fmt.Errorf("invalid duration %d: must be positive", c.Duration))
+	}
+	if c.LineLimit < 0 {
+		errs = append(errs, fmt.Errorf("invalid lineLimit %d: must not be negative", c.LineLimit))
+	}
+	if _, ok := parsePosition(c.Position); !ok {
+		errs = append(errs, fmt.Errorf("invalid position %q", c.Position))
+	}
+
+	switch c.Font {
+	case "", FontNerd, FontUnicode, FontASCII:
+	default:
+		errs = append(errs, fmt.Errorf("invalid font %q: must be %s, %s or %s", c.Font, FontNerd, FontUnicode, FontASCII))
+	}
+
+	for i, def := range c.AlertTypes {
+		if err := def.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("alertTypes[%d]: %w", i, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// NewAlertModelFromConfig creates and returns a new AlertModel configured by
+// cfg, or an error describing everything wrong with cfg.
+func NewAlertModelFromConfig(cfg Config) (AlertModel, error) {
+	if err := cfg.Validate(); err != nil {
+		return AlertModel{}, err
+	}
+
+	m := *NewAlertModel(cfg.Width, cfg.Font == FontNerd, cfg.Duration)
+	if cfg.Font == FontUnicode {
+		m = m.WithUnicodePrefix()
+	}
+	if pos, _ := parsePosition(cfg.Position); pos != UnspecifiedPosition {
+		m = m.WithPosition(pos)
+	}
+	if cfg.MinWidth > 0 {
+		m = m.WithMinWidth(cfg.MinWidth)
+	}
+	if cfg.TextWidth > 0 {
+		m = m.WithTextWidth(cfg.TextWidth)
+	}
+	if cfg.AllowEscToClose {
+		m = m.WithAllowEscToClose()
+	}
+	if cfg.LineLimit > 0 {
+		m = m.WithLineLimit(cfg.LineLimit)
+	}
+	if cfg.Ellipsis != "" {
+		m = m.WithEllipsis(cfg.Ellipsis)
+	}
+
+	// Registered last so font switches don't overwrite custom prefixes
+	for _, def := range cfg.AlertTypes {
+		m.RegisterNewAlertType(def)
+	}
+
+	return m, nil
+}
+
+// parsePosition resolves s, either a Position value or its String form.
+// An empty s resolves to UnspecifiedPosition.
+func parsePosition(s string) (Position, bool) {
+	if s == "" {
+		return UnspecifiedPosition, true
+	}
+	for _, pos := range []Position{
+		TopLeftPosition, TopCenterPosition, TopRightPosition,
+		BottomLeftPosition, BottomCenterPosition, BottomRightPosition,
+	} {
+		if s == string(pos) || s == pos.String() {
+			return pos, true
+		}
+	}
+	return UnspecifiedPosition, false
+}
@@ -0,0 +1,79 @@
+package bubbleup
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+const configJSON = `{
+	"width": 30,
+	"minWidth": 10,
+	"duration": 5,
+	"position": "bottom-right",
+	"font": "unicode",
+	"allowEscToClose": true,
+	"lineLimit": 3,
+	"ellipsis": "...",
+	"alertTypes": [
+		{"key": "Deploy", "foreColor": "#8800ff", "prefix": ">", "unicodePrefix": "▶", "kind": "lined", "duration": 2}
+	]
+}`
+
+func TestConfigFromJSON(t *testing.T) {
+	var cfg Config
+	if err := json.Unmarshal([]byte(configJSON), &cfg); err != nil {
+		t.Fatal(err)
+	}
+	m, err := NewAlertModelFromConfig(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if m.width != 30 || m.minWidth != 10 || m.lineLimit != 3 || m.getEllipsis() != "..." {
+		t.Errorf("widths, line limit or ellipsis not applied: %d %d %d %q", m.width, m.minWidth, m.lineLimit, m.getEllipsis())
+	}
+	if m.position != BottomRightPosition || m.FontMode() != FontUnicode || !m.allowEscToClose {
+		t.Errorf("position, font or esc not applied: %s %s %v", m.position, m.FontMode(), m.allowEscToClose)
+	}
+
+	clock := &fakeClock{}
+	m = m.WithTestMode().WithClock(clock)
+	m = raise(m, "Deploy", "shipped")
+	if !m.HasVisibleAlertOfType("Deploy") {
+		t.Fatal("expected the custom type to show")
+	}
+	assertContains(t, m.activeAlert.render(), "┃ ▶ shipped")
+	clock.advance(3 * time.Second)
+	if m = send(m, struct{}{}); m.HasActiveAlert() {
+		t.Error("expected the custom type's 2 second duration")
+	}
+
+	// And back again
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var again Config
+	if err := json.Unmarshal(data, &again); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewAlertModelFromConfig(again); err != nil {
+		t.Errorf("round-tripped config: %v", err)
+	}
+}
+
+func TestConfigValidateReportsEveryProblem(t *testing.T) {
+	cfg := Config{Width: 0, Duration: -1, Position: "middle", Font: "fancy",
+		AlertTypes: []AlertDefinition{{Key: "", ForeColor: "nope"}}}
+	_, err := NewAlertModelFromConfig(cfg)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	for _, want := range []string{"width", "duration", "position", "font", "alertTypes[0]"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q doesn't mention %s", err, want)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package bubbleup
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// Labels of the buttons on confirm alerts, with the selected one bracketed.
+const (
+	confirmYesLabel = "Yes"
+	confirmNoLabel  = "No"
+)
+
+// confirmation holds the commands a confirm alert chooses between.
+type confirmation struct {
+	onYes tea.Cmd
+	onNo  tea.Cmd
+}
+
+// NewConfirmAlertCmd returns the tea.Cmd that raises a sticky Warn alert at
+// the top center asking the user to confirm message, with Yes and No buttons
+// under it. No is selected to begin with. Left, right and tab move between
+// the buttons and enter picks one; y and n pick one directly, and esc always
+// picks No. Update returns onYes or onNo, either of which may be nil, once
+// the user picks. While it shows, check IsConfirming to keep those keys from
+// your own bindings. A newer alert replaces it without running either command.
+func (m AlertModel) NewConfirmAlertCmd(message string, onYes, onNo tea.Cmd) tea.Cmd {
+	return func() tea.Msg {
+		return alertMsg{
+			alertKey: WarnKey,
+			msg:      message,
+			sticky:   true,
+			confirm:  &confirmation{onYes: onYes, onNo: onNo},
+		}
+	}
+}
+
+// IsConfirming reports whether an alert from NewConfirmAlertCmd is waiting
+// for the user to pick Yes or No.
+func (m AlertModel) IsConfirming() bool {
+	return m.shown() && m.activeAlert.confirm != nil
+}
+
+// updateConfirm handles key presses for a confirm alert, returning the
+// chosen command once one is picked. Reports whether the key was handled.
+func (m *AlertModel) updateConfirm(msg tea.KeyMsg) (tea.Cmd, bool) {
+	n := m.activeAlert
+	switch msg.String() {
+	case "left", "right", "tab", "shift+tab", "h", "l":
+		n.confirmYes = !n.confirmYes
+		return nil, true
+	case "enter":
+		return m.pickConfirm(n.confirmYes), true
+	case "y":
+		return m.pickConfirm(true), true
+	case "n":
+		return m.pickConfirm(false), true
+	case "esc":
+		m.escConsumed = true
+		return m.pickConfirm(false), true
+	}
+	return nil, false
+}
+
+// pickConfirm closes the confirm alert, returning the chosen command along
+// with the next alert of any sequence.
+func (m *AlertModel) pickConfirm(yes bool) tea.Cmd {
+	n := m.activeAlert
+	m.notifyDismiss(DismissClosed)
+	m.activeAlert = nil
+
+	chosen := n.confirm.onNo
+	if yes {
+		chosen = n.confirm.onYes
+	}
+	return tea.Batch(chosen, m.advanceSequence(n))
+}
+
+// confirmButtons returns the button line of a confirm alert, bracketing the
+// selected button.
+func (n *alert) confirmButtons() string {
+	if n.confirmYes {
+		return "[" + confirmYesLabel + "]  " + confirmNoLabel + " "
+	}
+	return " " + confirmYesLabel + "  [" + confirmNoLabel + "]"
+}
@@ -0,0 +1,79 @@
+package bubbleup
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type (
+	yesMsg struct{}
+	noMsg  struct{}
+)
+
+func confirmModel() AlertModel {
+	m, _ := newTestModel()
+	m = m.WithWidth(30)
+	yes := func() tea.Msg { return yesMsg{} }
+	no := func() tea.Msg { return noMsg{} }
+	return send(m, m.NewConfirmAlertCmd("Delete file?", yes, no)())
+}
+
+func TestConfirmSelectsAndPicks(t *testing.T) {
+	m := confirmModel()
+	if !m.IsConfirming() {
+		t.Fatal("expected a confirm alert")
+	}
+	assertContains(t, stripANSI(m.activeAlert.render()), "Yes  [No]")
+	before := m.Fingerprint()
+
+	m = send(m, keyMsg("right"))
+	assertContains(t, stripANSI(m.activeAlert.render()), "[Yes]  No")
+	if m.Fingerprint() == before {
+		t.Error("expected the fingerprint to change with the selection")
+	}
+
+	out, cmd := m.Update(keyMsg("enter"))
+	if m = out.(AlertModel); m.IsConfirming() {
+		t.Error("expected picking to close the confirm alert")
+	}
+	if msgs := runCmd(cmd); len(msgs) != 1 || msgs[0] != (yesMsg{}) {
+		t.Errorf("enter on Yes ran %v, want onYes", msgs)
+	}
+}
+
+func TestConfirmEscPicksNo(t *testing.T) {
+	m := send(confirmModel(), keyMsg("right"))
+
+	out, cmd := m.Update(keyMsg("esc"))
+	if msgs := runCmd(cmd); len(msgs) != 1 || msgs[0] != (noMsg{}) {
+		t.Errorf("esc ran %v, want onNo", msgs)
+	}
+	if m = out.(AlertModel); !m.ConsumeEsc() {
+		t.Error("expected esc to be consumed")
+	}
+}
+
+func TestConfirmIgnoresOtherKeys(t *testing.T) {
+	m := send(confirmModel(), keyMsg("q"))
+	if !m.IsConfirming() || !strings.Contains(stripANSI(m.activeAlert.render()), "[No]") {
+		t.Error("expected unrelated keys to leave the confirm alert alone")
+	}
+}
+
+func TestConfirmButtonsSurviveLineLimit(t *testing.T) {
+	m, _ := newTestModel()
+	m = m.WithWidth(30).WithLineLimit(2)
+	m = send(m, m.NewConfirmAlertCmd(strings.Repeat("really delete everything? ", 6), nil, nil)())
+
+	out := stripANSI(m.activeAlert.render())
+	assertContains(t, out, "lines)")
+	assertContains(t, out, "Yes  [No]")
+
+	// The dismiss hint is kept too
+	m.activeAlert.dismissArmed = true
+	out = stripANSI(m.activeAlert.render())
+	assertContains(t, out, "Yes  [No]")
+	assertContains(t, out, dismissAgainHint)
+}
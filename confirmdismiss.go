@@ -0,0 +1,37 @@
+package bubbleup
+
+// dismissAgainHint is shown under an alert waiting for a second dismiss key
+// press, see WithConfirmDismiss.
+const dismissAgainHint = "Press again to dismiss"
+
+// WithConfirmDismiss returns a new AlertModel where closing an alert of the
+// given types, with esc or the WithDismissAllKey key, takes a second press
+// of the key, guarding critical alerts against being closed by accident. The
+// first press shows a hint under the message, and any other key cancels it.
+// With no keys, every alert type needs the second press. This is an
+// immutable operation.
+func (m AlertModel) WithConfirmDismiss(keys ...string) AlertModel {
+	m.confirmDismiss = true
+	m.confirmKeys = nil
+	if len(keys) > 0 {
+		m.confirmKeys = make(map[string]bool, len(keys))
+		for _, key := range keys {
+			m.confirmKeys[key] = true
+		}
+	}
+	return m
+}
+
+// armDismiss reports whether a dismiss key press should only ask for a
+// second press, arming the active alert for it.
+func (m AlertModel) armDismiss() bool {
+	n := m.activeAlert
+	if !m.confirmDismiss || n.dismissArmed {
+		return false
+	}
+	if m.confirmKeys != nil && !m.confirmKeys[n.key] {
+		return false
+	}
+	n.dismissArmed = true
+	return true
+}
@@ -0,0 +1,41 @@
+package bubbleup
+
+import "testing"
+
+func TestConfirmDismissTakesTwoPresses(t *testing.T) {
+	m, _ := newTestModel()
+	m = m.WithAllowEscToClose().WithConfirmDismiss(ErrorKey).WithWidth(30)
+	m = raise(m, ErrorKey, "disk failing")
+	before := m.Fingerprint()
+
+	if m = send(m, keyMsg("esc")); m.activeAlert == nil {
+		t.Fatal("expected one press not to dismiss")
+	}
+	assertContains(t, stripANSI(m.activeAlert.render()), dismissAgainHint)
+	if m.Fingerprint() == before {
+		t.Error("expected the fingerprint to change with the hint")
+	}
+	if !m.ConsumeEsc() {
+		t.Error("expected the first esc to be consumed")
+	}
+
+	if m = send(m, keyMsg("esc")); m.activeAlert != nil {
+		t.Error("expected the second press to dismiss")
+	}
+}
+
+func TestConfirmDismissCancelledByOtherKeys(t *testing.T) {
+	m, _ := newTestModel()
+	m = m.WithAllowEscToClose().WithConfirmDismiss(ErrorKey).WithWidth(30)
+	m = send(raise(m, ErrorKey, "disk failing"), keyMsg("esc"), keyMsg("x"))
+
+	assertNotContains(t, stripANSI(m.activeAlert.render()), dismissAgainHint)
+	if m = send(m, keyMsg("esc")); m.activeAlert == nil {
+		t.Error("expected esc after another key to only ask again")
+	}
+
+	// Other types close on the first press
+	if m = send(raise(m, InfoKey, "fine"), keyMsg("esc")); m.activeAlert != nil {
+		t.Error("expected an Info alert to close on one press")
+	}
+}
@@ -0,0 +1,60 @@
+package bubbleup
+
+import "github.com/lucasb-eyer/go-colorful"
+
+// WithEnsureContrast returns a new AlertModel that lightens or darkens the
+// icon and text colors of alerts, keeping their hue, until they have at least
+// the given WCAG contrast ratio against the background, e.g. 4.5 for normal
+// text. Colors that already meet it are left alone. A ratio of 0 turns the
+// adjustment off. This is an immutable operation.
+func (m AlertModel) WithEnsureContrast(ratio float64) AlertModel {
+	m.minContrast = ratio
+	return m
+}
+
+// contrastRatio returns the WCAG contrast ratio between a and b, from 1 for
+// identical colors up to 21 for black on white.
+func contrastRatio(a, b colorful.Color) float64 {
+	la, lb := relativeLuminance(a), relativeLuminance(b)
+	if la < lb {
+		la, lb = lb, la
+	}
+	return (la + 0.05) / (lb + 0.05)
+}
+
+// relativeLuminance returns the WCAG relative luminance of c.
+func relativeLuminance(c colorful.Color) float64 {
+	r, g, b := c.LinearRgb()
+	return 0.2126*r + 0.7152*g + 0.0722*b
+}
+
+// ensureContrast returns fg with its lightness moved away from bg just far
+// enough to reach ratio.
+func ensureContrast(fg, bg colorful.Color, ratio float64) colorful.Color {
+	if ratio <= 0 || contrastRatio(fg, bg) >= ratio {
+		return fg
+	}
+
+	// Move towards white on dark backgrounds and black on light ones
+	h, c, l := fg.Hcl()
+	target := 1.0
+	if relativeLuminance(bg) > 0.5 {
+		target = 0
+	}
+	if contrastRatio(colorful.Hcl(h, c, target).Clamped(), bg) < ratio {
+		// Too saturated to get there, so give up the color for gray
+		c = 0
+	}
+
+	// Binary search the smallest lightness change that is enough
+	lo, hi := l, target
+	for range 20 {
+		mid := (lo + hi) / 2
+		if contrastRatio(colorful.Hcl(h, c, mid).Clamped(), bg) >= ratio {
+			hi = mid
+		} else {
+			lo = mid
+		}
+	}
+	return colorful.Hcl(h, c, hi).Clamped()
+}
@@ -0,0 +1,43 @@
+package bubbleup
+
+import (
+	"testing"
+
+	"github.com/lucasb-eyer/go-colorful"
+)
+
+func TestEnsureContrastMeetsRatio(t *testing.T) {
+	for _, tt := range []struct{ fg, bg string }{
+		{"#333344", "#000000"},
+		{"#DDDDCC", "#FFFFFF"},
+		{"#0000FF", "#000000"},
+	} {
+		fg, _ := colorful.Hex(tt.fg)
+		bg, _ := colorful.Hex(tt.bg)
+		if contrastRatio(fg, bg) >= 4.5 {
+			t.Fatalf("%s on %s already has enough contrast", tt.fg, tt.bg)
+		}
+		if got := contrastRatio(ensureContrast(fg, bg, 4.5), bg); got < 4.5 {
+			t.Errorf("%s on %s adjusted to ratio %.2f, want at least 4.5", tt.fg, tt.bg, got)
+		}
+	}
+
+	// Readable colors are left alone
+	white, _ := colorful.Hex("#FFFFFF")
+	black, _ := colorful.Hex("#000000")
+	if got := ensureContrast(white, black, 4.5); got != white {
+		t.Errorf("expected white on black unchanged, got %s", got.Hex())
+	}
+}
+
+func TestEnsureContrastAppliesToAlerts(t *testing.T) {
+	m, _ := newTestModel()
+	m.RegisterNewAlertType(AlertDefinition{Key: "Dim", ForeColor: "#202028", Prefix: "(.)"})
+	n := raise(m.WithEnsureContrast(4.5), "Dim", "hard to read").activeAlert
+
+	for name, c := range map[string]colorful.Color{"icon": n.iconColor, "text": n.textColor} {
+		if got := contrastRatio(c, backColor); got < 4.5 {
+			t.Errorf("%s color %s has ratio %.2f, want at least 4.5", name, c.Hex(), got)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package bubbleup
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DebugLayout returns a plain text description of the active alert's
+// layout, for diagnosing width and wrapping issues without reading escape
+// codes: its type and position, the column and line of its top-left corner
+// as placed over content filling the terminal, with any WithEdgeInset it was
+// last rendered with, its size in cells, and each line of its box with colors
+// stripped. The corner is only given once the terminal size is known from a
+// tea.WindowSizeMsg.
+func (m AlertModel) DebugLayout() string {
+	if !m.shown() {
+		return "no active alert\n"
+	}
+
+	lines, width := getLines(m.renderActiveAlert())
+	height := len(lines)
+	pos := m.activeAlert.position
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "alert %q at %s: ", m.activeAlert.key, pos)
+	cols, rows := m.terminalWidth(), m.terminalHeight()
+	if cols > 0 && rows > 0 {
+		x, y := m.alertOrigin(width, height, cols, rows)
+		fmt.Fprintf(&b, "x=%d y=%d ", x, y)
+	}
+	fmt.Fprintf(&b, "width=%d height=%d\n", width, height)
+	for _, line := range lines {
+		fmt.Fprintf(&b, "  |%s|\n", stripANSI(line))
+	}
+	return b.String()
+}
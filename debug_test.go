@@ -0,0 +1,76 @@
+package bubbleup
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// drawnCorner returns the cell of the alert's top-left border corner in the
+// rendering of m over content, or -1, -1 if it isn't drawn.
+func drawnCorner(m AlertModel, content string) (x, y int) {
+	for y, line := range strings.Split(stripANSI(m.Render(content)), "\n") {
+		if i := strings.Index(line, "╭"); i >= 0 {
+			return len([]rune(line[:i])), y
+		}
+	}
+	return -1, -1
+}
+
+func TestDebugLayoutDescribesAlert(t *testing.T) {
+	m, _ := newTestModel()
+	if got := m.DebugLayout(); got != "no active alert\n" {
+		t.Errorf("DebugLayout() without an alert = %q", got)
+	}
+
+	m = m.WithPosition(BottomRightPosition)
+	m = raise(m, InfoKey, "hi")
+	got := m.DebugLayout()
+	assertContains(t, got, `alert "Info" at bottom-right: width=22 height=3`)
+	assertNotContains(t, got, "x=")
+	assertContains(t, got, "  |│ (i) hi")
+
+	m = send(m, tea.WindowSizeMsg{Width: 40, Height: 10})
+	x, y := drawnCorner(m, blank(40, 10))
+	assertContains(t, m.DebugLayout(), fmt.Sprintf("x=%d y=%d width=22 height=3", x, y))
+}
+
+func TestDebugLayoutFollowsSidebar(t *testing.T) {
+	for _, side := range []lipgloss.Position{lipgloss.Left, lipgloss.Right} {
+		m, _ := newTestModel()
+		m = m.WithSidebar(24, side).WithPosition(TopRightPosition)
+		m = send(m, tea.WindowSizeMsg{Width: 50, Height: 5})
+		m = raise(m, InfoKey, "hi")
+
+		x, y := drawnCorner(m, fullContent(50, 5))
+		if x < 0 {
+			t.Fatalf("side %v: expected the alert to be drawn", side)
+		}
+		want := fmt.Sprintf("x=%d y=%d ", x, y)
+		if got := m.DebugLayout(); !strings.Contains(got, want) {
+			t.Errorf("side %v: DebugLayout() = %q, want it to contain %q", side, got, want)
+		}
+	}
+}
+
+func TestEdgeInsetSharedByDebugLayoutAndClicks(t *testing.T) {
+	m := clickModel().WithEdgeInset(1)
+	m = send(m, tea.WindowSizeMsg{Width: 40, Height: 10})
+	m = raiseClickable(m)
+	content := lipgloss.NewStyle().Border(lipgloss.NormalBorder()).Width(38).Height(8).Render("")
+
+	x, y := drawnCorner(m, content)
+	if x != 1 || y != 1 {
+		t.Fatalf("alert drawn at (%d, %d), want (1, 1) inside the content's border", x, y)
+	}
+	assertContains(t, m.DebugLayout(), "x=1 y=1 ")
+	if _, msgs := click(m, clickAt(0, 0)); len(msgs) != 0 {
+		t.Errorf("click on the content's border returned %v, want nothing", msgs)
+	}
+	if _, msgs := click(m, clickAt(1, 1)); len(msgs) != 1 {
+		t.Errorf("click on the alert's corner returned %v, want the OnClick message", msgs)
+	}
+}
@@ -0,0 +1,121 @@
+package bubbleup
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/lucasb-eyer/go-colorful"
+)
+
+// Validate reports every problem with the definition: a missing key, an
+// invalid color, a negative duration or an unknown kind.
+func (d AlertDefinition) Validate() error {
+	var errs []error
+
+	if d.Key == "" {
+		errs = append(errs, errors.New("missing key"))
+	}
+	if _, err := colorful.Hex(d.ForeColor); err != nil {
+		errs = append(errs, fmt.Errorf("foreColor: %w", err))
+	}
+	for _, opt := range []struct{ field, hex string }{
+		{"borderColor", d.BorderColor},
+		{"iconColor", d.IconColor},
+		{"textColor", d.TextColor},
+	} {
+		if opt.hex == "" {
+			continue
+		}
+		if _, err := colorful.Hex(opt.hex); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", opt.field, err))
+		}
+	}
+	if d.Duration < 0 {
+		errs = append(errs, fmt.Errorf("invalid duration %d: must not be negative", d.Duration))
+	}
+	if d.Kind.String() == "unknown" {
+		errs = append(errs, fmt.Errorf("invalid kind %d", d.Kind))
+	}
+
+	return errors.Join(errs...)
+}
+
+// AlertDefinitionBuilder builds an AlertDefinition step by step, as an
+// alternative to a struct literal. Create one with NewAlertDefinition.
+type AlertDefinitionBuilder struct {
+	def AlertDefinition
+}
+
+// NewAlertDefinition returns a builder for an alert type with the given key.
+func NewAlertDefinition(key string) AlertDefinitionBuilder {
+	return AlertDefinitionBuilder{def: AlertDefinition{Key: key}}
+}
+
+// WithColors sets the alert's foreground color, used for its border, icon
+// and text unless set separately. This is an immutable operation.
+func (b AlertDefinitionBuilder) WithColors(fore string) AlertDefinitionBuilder {
+	b.def.ForeColor = fore
+	return b
+}
+
+// WithBorderColor sets the color of the alert's border.
+// This is an immutable operation.
+func (b AlertDefinitionBuilder) WithBorderColor(hex string) AlertDefinitionBuilder {
+	b.def.BorderColor = hex
+	return b
+}
+
+// WithIconColor sets the color of the alert's prefix icon.
+// This is an immutable operation.
+func (b AlertDefinitionBuilder) WithIconColor(hex string) AlertDefinitionBuilder {
+	b.def.IconColor = hex
+	return b
+}
+
+// WithTextColor sets the color of the alert's message text.
+// This is an immutable operation.
+func (b AlertDefinitionBuilder) WithTextColor(hex string) AlertDefinitionBuilder {
+	b.def.TextColor = hex
+	return b
+}
+
+// WithPrefixes sets the prefix shown for each font option: ascii by default,
+// unicode with WithUnicodePrefix and nerd when NerdFont is enabled. Empty
+// prefixes fall back to ascii. This is an immutable operation.
+func (b AlertDefinitionBuilder) WithPrefixes(ascii, unicode, nerd string) AlertDefinitionBuilder {
+	b.def.Prefix = ascii
+	b.def.UnicodePrefix = unicode
+	b.def.NerdPrefix = nerd
+	return b
+}
+
+// WithDefaultDuration sets how long alerts of this type display, in seconds,
+// overriding the model's duration. This is an immutable operation.
+func (b AlertDefinitionBuilder) WithDefaultDuration(d time.Duration) AlertDefinitionBuilder {
+	b.def.Duration = d
+	return b
+}
+
+// WithKind sets how the alert is framed. This is an immutable operation.
+func (b AlertDefinitionBuilder) WithKind(kind AlertKind) AlertDefinitionBuilder {
+	b.def.Kind = kind
+	return b
+}
+
+// WithStyle sets the lipgloss.Style used to render the alert.
+// This is an immutable operation.
+func (b AlertDefinitionBuilder) WithStyle(style lipgloss.Style) AlertDefinitionBuilder {
+	b.def.Style = style
+	return b
+}
+
+// Build returns the finished AlertDefinition, or an error describing every
+// problem with it, such as a missing foreground color.
+func (b AlertDefinitionBuilder) Build() (AlertDefinition, error) {
+	if err := b.def.Validate(); err != nil {
+		return AlertDefinition{}, fmt.Errorf("alert type %q: %w", b.def.Key, err)
+	}
+	return b.def, nil
+}
@@ -0,0 +1,45 @@
+package bubbleup
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBuildAndRegisterCustomType(t *testing.T) {
+	// Durations are in seconds, as for NewAlertModel
+	def, err := NewAlertDefinition("Deploy").
+		WithColors("#00AAFF").
+		WithPrefixes("[>]", "▶", "").
+		WithDefaultDuration(3).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error: %v", err)
+	}
+
+	m, clock := newTestModel()
+	m.RegisterNewAlertType(def)
+	m = raise(m, "Deploy", "shipping")
+	if m.activeAlert == nil {
+		t.Fatal("expected the custom type to show")
+	}
+	assertContains(t, stripANSI(m.activeAlert.render()), "[>] shipping")
+
+	// The type's own duration replaces the model's 10 seconds
+	clock.advance(4 * time.Second)
+	if m = send(m, struct{}{}); m.activeAlert != nil {
+		t.Error("expected the custom type to expire after its 3 seconds")
+	}
+}
+
+func TestBuildRejectsIncompleteDefinitions(t *testing.T) {
+	_, err := NewAlertDefinition("").WithDefaultDuration(-time.Second).Build()
+	if err == nil {
+		t.Fatal("expected an error for an incomplete definition")
+	}
+	for _, want := range []string{"missing key", "foreColor", "negative"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q doesn't mention %q", err, want)
+		}
+	}
+}
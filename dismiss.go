@@ -0,0 +1,104 @@
+package bubbleup
+
+// DismissReason describes why an alert went away.
+type DismissReason int
+
+const (
+	// DismissExpired means the alert's duration ran out.
+	DismissExpired DismissReason = iota
+
+	// DismissClosed means the user closed the alert, for example with esc.
+	DismissClosed
+
+	// DismissReplaced means a newer alert took the alert's place.
+	DismissReplaced
+
+	// DismissExpiredDuringBlur means the alert's duration ran out while the
+	// terminal didn't have focus, so the user likely never saw it. Focus is
+	// only known when the program reports it, see tea.WithReportFocus.
+	DismissExpiredDuringBlur
+
+	// DismissSuppressedByDoNotDisturb means the alert was never shown because
+	// do not disturb was on, either dropping it or replacing it with a newer
+	// held alert.
+	DismissSuppressedByDoNotDisturb
+
+	// DismissRejectedDuplicateID means the alert was never shown because the
+	// active alert already used its ID, under IDCollisionReject.
+	DismissRejectedDuplicateID
+
+	// DismissSuppressedAsRepeat means the alert was never shown because an
+	// identical one appeared within the WithSuppressRepeatsWithin window.
+	DismissSuppressedAsRepeat
+
+	// DismissDone means the message an alert from NewAlertUntilCmd was
+	// waiting for arrived.
+	DismissDone
+
+	// DismissSnoozed means the alert was hidden by SnoozeCmd, to be shown
+	// again later.
+	DismissSnoozed
+
+	// DismissProgrammatic means the app cleared the alert, with
+	// DismissByKeyCmd.
+	DismissProgrammatic
+)
+
+func (r DismissReason) String() string {
+	switch r {
+	case DismissExpired:
+		return "expired"
+	case DismissClosed:
+		return "closed"
+	case DismissReplaced:
+		return "replaced"
+	case DismissExpiredDuringBlur:
+		return "expired during blur"
+	case DismissSuppressedByDoNotDisturb:
+		return "suppressed by do not disturb"
+	case DismissRejectedDuplicateID:
+		return "rejected duplicate id"
+	case DismissSuppressedAsRepeat:
+		return "suppressed as repeat"
+	case DismissDone:
+		return "done"
+	case DismissSnoozed:
+		return "snoozed"
+	case DismissProgrammatic:
+		return "programmatic"
+	default:
+		return "unknown"
+	}
+}
+
+// WithOnDismiss returns a new AlertModel that calls fn whenever an alert is
+// dismissed, with the spec the alert was created from (including its
+// Metadata) and the reason it went away. Alerts that never showed, for
+// example because of do not disturb, are reported too. fn is called synchronously from
+// Update, so it should return quickly. This is an immutable operation.
+func (m AlertModel) WithOnDismiss(fn func(spec AlertSpec, reason DismissReason)) AlertModel {
+	m.onDismiss = fn
+	return m
+}
+
+// notifyDismiss reports the active alert's dismissal to the OnDismiss hook
+// and the event sink.
+// It doesn't clear the active alert; callers do that themselves.
+func (m AlertModel) notifyDismiss(reason DismissReason) {
+	if m.activeAlert == nil {
+		return
+	}
+	m.publish(AlertDismissed, m.activeAlert.spec(), reason)
+	if m.onDismiss != nil {
+		m.onDismiss(m.activeAlert.spec(), reason)
+	}
+}
+
+// notifySuppressed reports an alert that was never shown to the OnDismiss
+// hook and the event sink.
+func (m AlertModel) notifySuppressed(msg alertMsg, reason DismissReason) {
+	m.publish(AlertDismissed, msg.spec(), reason)
+	if m.onDismiss != nil {
+		m.onDismiss(msg.spec(), reason)
+	}
+}
@@ -0,0 +1,131 @@
+package bubbleup
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// dismissal is one call of the OnDismiss hook.
+type dismissal struct {
+	spec   AlertSpec
+	reason DismissReason
+}
+
+// recordDismissals returns m reporting every dismissal to the returned slice.
+func recordDismissals(m AlertModel) (AlertModel, *[]dismissal) {
+	var got []dismissal
+	return m.WithOnDismiss(func(spec AlertSpec, reason DismissReason) {
+		got = append(got, dismissal{spec, reason})
+	}), &got
+}
+
+func TestMetadataReachesOnDismiss(t *testing.T) {
+	m, clock := newTestModel()
+	m, got := recordDismissals(m)
+
+	m = send(m, m.NewAlertCmdFromSpec(AlertSpec{
+		Key:      InfoKey,
+		Message:  "uploaded",
+		Metadata: map[string]any{"request": "r-42", "user": 7},
+	})())
+	clock.advance(11 * time.Second)
+	send(m, struct{}{})
+
+	if len(*got) != 1 {
+		t.Fatalf("got %d dismissals, want 1", len(*got))
+	}
+	d := (*got)[0]
+	if d.reason != DismissExpired {
+		t.Errorf("reason = %s, want %s", d.reason, DismissExpired)
+	}
+	if d.spec.Metadata["request"] != "r-42" || d.spec.Metadata["user"] != 7 {
+		t.Errorf("metadata = %v, want request r-42 and user 7", d.spec.Metadata)
+	}
+}
+
+func TestMetadataDoesntAffectRendering(t *testing.T) {
+	m, _ := newTestModel()
+	plain := raise(m, InfoKey, "uploaded").Render(blank(30, 5))
+	tagged := send(m, m.NewAlertCmdFromSpec(AlertSpec{
+		Key: InfoKey, Message: "uploaded", Metadata: map[string]any{"request": "r-42"},
+	})()).Render(blank(30, 5))
+	if plain != tagged {
+		t.Errorf("metadata changed the rendering:\n%s\nvs\n%s", plain, tagged)
+	}
+}
+
+func TestExpiredDuringBlur(t *testing.T) {
+	for _, testMode := range []bool{false, true} {
+		clock := &fakeClock{now: time.Unix(0, 0)}
+		m := NewAlertModel(20, false, 2).WithClock(clock)
+		if testMode {
+			m = m.WithTestMode()
+		}
+		m, got := recordDismissals(m)
+
+		m = send(m, tea.BlurMsg{})
+		out, cmd := m.Update(m.NewAlertCmd(InfoKey, "unseen")())
+		m = out.(AlertModel)
+		clock.advance(3 * time.Second)
+		if testMode {
+			m = send(m, struct{}{})
+		} else {
+			m = send(m, runCmd(cmd)...)
+		}
+
+		if len(*got) != 1 || (*got)[0].reason != DismissExpiredDuringBlur {
+			t.Errorf("test mode %v: dismissals = %v, want one expired during blur", testMode, *got)
+		}
+	}
+}
+
+func TestExpiredWhileFocused(t *testing.T) {
+	m, clock := newTestModel()
+	m, got := recordDismissals(m)
+
+	m = send(m, tea.BlurMsg{}, tea.FocusMsg{})
+	m = raise(m, InfoKey, "seen")
+	clock.advance(11 * time.Second)
+	send(m, struct{}{})
+
+	if len(*got) != 1 || (*got)[0].reason != DismissExpired {
+		t.Errorf("dismissals = %v, want one expired", *got)
+	}
+}
+
+func TestDismissReasonsHaveNames(t *testing.T) {
+	for r := DismissExpired; r <= DismissProgrammatic; r++ {
+		if r.String() == "unknown" {
+			t.Errorf("DismissReason(%d) has no name", r)
+		}
+	}
+	if DismissReason(-1).String() != "unknown" {
+		t.Error("expected invalid reasons to be unknown")
+	}
+}
+
+type saveDoneMsg struct{}
+
+func TestUntilAlertDismissesOnMatchingMessage(t *testing.T) {
+	m, clock := newTestModel()
+	m, got := recordDismissals(m)
+	m = send(m, m.NewAlertUntilCmd(InfoKey, "Saving...", func(msg tea.Msg) bool {
+		_, ok := msg.(saveDoneMsg)
+		return ok
+	})())
+
+	// Neither time nor other messages end it
+	clock.advance(time.Minute)
+	if m = send(m, struct{}{}, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")}); m.activeAlert == nil {
+		t.Fatal("expected the alert to wait for its message")
+	}
+
+	if m = send(m, saveDoneMsg{}); m.activeAlert != nil {
+		t.Fatal("expected the matching message to dismiss the alert")
+	}
+	if len(*got) != 1 || (*got)[0].reason != DismissDone || (*got)[0].spec.Message != "Saving..." {
+		t.Errorf("dismissals = %v, want the alert reported as done", *got)
+	}
+}
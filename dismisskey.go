@@ -0,0 +1,93 @@
+package bubbleup
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// dismissKeyMsg is the tea.Msg used to clear every alert of a type
+type dismissKeyMsg struct {
+	key string
+}
+
+// DismissByKeyCmd returns the tea.Cmd that clears every alert of type key,
+// whether shown, held back by do not disturb, snoozed, waiting in the
+// ticker or waiting in a sequence, e.g. clearing "offline" warnings once
+// reconnected. Each is reported to the OnDismiss hook with
+// DismissProgrammatic. Alerts of other types are left alone.
+func (m AlertModel) DismissByKeyCmd(key string) tea.Cmd {
+	return func() tea.Msg {
+		return dismissKeyMsg{key: key}
+	}
+}
+
+// dismissByKey clears every alert of type key, returning the tea.Cmd that
+// shows the next alert of a sequence the active alert was part of.
+func (m *AlertModel) dismissByKey(key string) tea.Cmd {
+	if m.heldAlert != nil && m.heldAlert.alertKey == key {
+		m.notifySuppressed(*m.heldAlert, DismissProgrammatic)
+		m.heldAlert = nil
+	}
+
+	// Rebuilt rather than filtered in place, since earlier copies of the
+	// model share the slices
+	var snoozed []snoozedAlert
+	for _, s := range m.snoozed {
+		if s.msg.alertKey == key {
+			m.notifySuppressed(s.msg, DismissProgrammatic)
+			continue
+		}
+		snoozed = append(snoozed, s)
+	}
+	m.snoozed = snoozed
+
+	var ticker []*alert
+	for _, n := range m.ticker {
+		if n.key == key {
+			m.notifyWaiting(n, DismissProgrammatic)
+			continue
+		}
+		ticker = append(ticker, n)
+	}
+	m.ticker = ticker
+
+	var sequence []alertMsg
+	for _, step := range m.sequence {
+		if step.alertKey == key {
+			m.notifySuppressed(step, DismissProgrammatic)
+			continue
+		}
+		sequence = append(sequence, step)
+	}
+	m.sequence = sequence
+
+	if m.activeAlert == nil || m.activeAlert.key != key {
+		return nil
+	}
+	m.notifyDismiss(DismissProgrammatic)
+	dismissed := m.activeAlert
+	m.activeAlert = nil
+	return m.advanceSequence(dismissed)
+}
+
+// closeAll clears every alert for the dismiss-all key: the active one and
+// any held back by do not disturb, snoozed, waiting in the ticker or waiting
+// in a sequence. Each is reported to the OnDismiss hook with DismissClosed.
+func (m *AlertModel) closeAll() {
+	m.notifyDismiss(DismissClosed)
+	m.activeAlert = nil
+
+	if m.heldAlert != nil {
+		m.notifySuppressed(*m.heldAlert, DismissClosed)
+		m.heldAlert = nil
+	}
+	for _, s := range m.snoozed {
+		m.notifySuppressed(s.msg, DismissClosed)
+	}
+	m.snoozed = nil
+	for _, n := range m.ticker {
+		m.notifyWaiting(n, DismissClosed)
+	}
+	m.ticker = nil
+	for _, step := range m.sequence {
+		m.notifySuppressed(step, DismissClosed)
+	}
+	m.sequence = nil
+}
@@ -0,0 +1,72 @@
+package bubbleup
+
+import (
+	"testing"
+	"time"
+)
+
+// reportedReasons returns the reason each recorded dismissal was reported
+// with, by message.
+func reportedReasons(got []dismissal) map[string]DismissReason {
+	reported := map[string]DismissReason{}
+	for _, d := range got {
+		reported[d.spec.Message] = d.reason
+	}
+	return reported
+}
+
+func TestDismissByKeyClearsOnlyThatKey(t *testing.T) {
+	m, _ := newTestModel()
+	m, got := recordDismissals(m.WithDoNotDisturbMode(DoNotDisturbQueue))
+	m = send(m, m.NewAlertCmdWithID("w", WarnKey, "snoozed warn")())
+	m = send(m, m.SnoozeCmd("w", time.Minute)())
+	m = send(m, m.NewAlertCmdWithID("i", InfoKey, "snoozed info")())
+	m = send(m, m.SnoozeCmd("i", time.Minute)())
+	m = send(m, m.NewAlertSequenceCmd([]AlertSpec{
+		{Key: WarnKey, Message: "shown warn"},
+		{Key: InfoKey, Message: "next info"},
+		{Key: WarnKey, Message: "later warn"},
+	})())
+	m, _ = m.SetDoNotDisturb(true)
+	m = raise(m, WarnKey, "held warn")
+
+	*got = nil
+	out, cmd := m.Update(m.DismissByKeyCmd(WarnKey)())
+	m = send(out.(AlertModel), runCmd(cmd)...)
+
+	reported := reportedReasons(*got)
+	for _, message := range []string{"shown warn", "held warn", "snoozed warn", "later warn"} {
+		if reason, ok := reported[message]; !ok || reason != DismissProgrammatic {
+			t.Errorf("%q reported as %v (%v), want %s", message, reason, ok, DismissProgrammatic)
+		}
+	}
+	if len(reported) != 4 {
+		t.Errorf("dismissals = %v, want only the warnings", *got)
+	}
+	if len(m.snoozed) != 1 || m.snoozed[0].msg.msg != "snoozed info" {
+		t.Errorf("expected the snoozed info alert to stay snoozed, got %v", m.snoozed)
+	}
+
+	// The sequence carries on with the step of the other type
+	m, cmd = m.SetDoNotDisturb(false)
+	if m = send(m, runCmd(cmd)...); m.activeAlert == nil || m.activeAlert.message != "next info" {
+		t.Error("expected the sequence to move on to the info step")
+	}
+}
+
+func TestDismissByKeyClearsTicker(t *testing.T) {
+	m, _ := newTestModel()
+	m, got := recordDismissals(m.WithTickerMode(time.Second))
+	m = raise(m, InfoKey, "first info")
+	m = raise(m, WarnKey, "warn")
+	m = raise(m, InfoKey, "second info")
+
+	m = send(m, m.DismissByKeyCmd(InfoKey)())
+	reported := reportedReasons(*got)
+	if len(reported) != 2 || reported["first info"] != DismissProgrammatic || reported["second info"] != DismissProgrammatic {
+		t.Errorf("dismissals = %v, want both info alerts as %s", *got, DismissProgrammatic)
+	}
+	if m.activeAlert == nil || m.activeAlert.message != "warn" || len(m.ticker) != 0 {
+		t.Error("expected the warning to be shown with nothing left waiting")
+	}
+}
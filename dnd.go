@@ -0,0 +1,71 @@
+package bubbleup
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// DoNotDisturbMode controls what happens to alerts raised while
+// do not disturb is on.
+type DoNotDisturbMode int
+
+const (
+	// DoNotDisturbDrop discards alerts raised during do not disturb.
+	DoNotDisturbDrop DoNotDisturbMode = iota
+
+	// DoNotDisturbQueue holds back alerts raised during do not disturb and
+	// shows them once it is turned off. Only the most recent alert is kept,
+	// since it would have replaced any earlier one anyway.
+	DoNotDisturbQueue
+)
+
+// WithDoNotDisturbMode returns a new AlertModel that handles alerts raised
+// during do not disturb according to mode. Defaults to DoNotDisturbDrop.
+// This is an immutable operation.
+func (m AlertModel) WithDoNotDisturbMode(mode DoNotDisturbMode) AlertModel {
+	m.dndMode = mode
+	return m
+}
+
+// WithDoNotDisturbKey returns a new AlertModel where pressing key toggles
+// do not disturb. This is an immutable operation.
+func (m AlertModel) WithDoNotDisturbKey(key string) AlertModel {
+	m.dndKey = key
+	return m
+}
+
+// SetDoNotDisturb returns a new AlertModel with do not disturb turned on or
+// off. While on, incoming alerts are muted without affecting the model's
+// configuration. Turning it off returns the tea.Cmd that shows any alert
+// held back in DoNotDisturbQueue mode; be sure to return it from Update.
+func (m AlertModel) SetDoNotDisturb(on bool) (AlertModel, tea.Cmd) {
+	m.doNotDisturb = on
+	if on || m.heldAlert == nil {
+		return m, nil
+	}
+
+	held := *m.heldAlert
+	m.heldAlert = nil
+	return m, func() tea.Msg {
+		return held
+	}
+}
+
+// IsDoNotDisturb reports whether do not disturb is on.
+func (m AlertModel) IsDoNotDisturb() bool {
+	return m.doNotDisturb
+}
+
+// holdForDoNotDisturb mutes msg while do not disturb is on, keeping it
+// for later in DoNotDisturbQueue mode. Reports whether msg was muted.
+func (m *AlertModel) holdForDoNotDisturb(msg alertMsg) bool {
+	if !m.doNotDisturb {
+		return false
+	}
+	if m.dndMode != DoNotDisturbQueue {
+		m.notifySuppressed(msg, DismissSuppressedByDoNotDisturb)
+		return true
+	}
+	if m.heldAlert != nil {
+		m.notifySuppressed(*m.heldAlert, DismissSuppressedByDoNotDisturb)
+	}
+	m.heldAlert = &msg
+	return true
+}
@@ -0,0 +1,56 @@
+package bubbleup
+
+import "testing"
+
+func TestDoNotDisturbQueueSurfacesAfterwards(t *testing.T) {
+	m, _ := newTestModel()
+	m, got := recordDismissals(m.WithDoNotDisturbMode(DoNotDisturbQueue))
+	m, _ = m.SetDoNotDisturb(true)
+
+	m = raise(m, InfoKey, "first")
+	m = raise(m, WarnKey, "second")
+	if m.HasActiveAlert() {
+		t.Fatal("alerts shouldn't show during do not disturb")
+	}
+	if !m.HasActiveAlertOfType(WarnKey) {
+		t.Error("the held alert should count as waiting")
+	}
+
+	m, cmd := m.SetDoNotDisturb(false)
+	m = send(m, runCmd(cmd)...)
+	if !m.HasVisibleAlertOfType(WarnKey) || m.activeAlert.message != "second" {
+		t.Error("expected the latest held alert to show once do not disturb is off")
+	}
+	if len(*got) != 1 || (*got)[0].spec.Message != "first" || (*got)[0].reason != DismissSuppressedByDoNotDisturb {
+		t.Errorf("dismissals = %v, want only the replaced held alert", *got)
+	}
+}
+
+func TestDoNotDisturbDropDiscards(t *testing.T) {
+	m, _ := newTestModel()
+	m, got := recordDismissals(m)
+	m, _ = m.SetDoNotDisturb(true)
+
+	m = raise(m, InfoKey, "muted")
+	m, cmd := m.SetDoNotDisturb(false)
+	if cmd != nil || m.HasActiveAlert() {
+		t.Error("dropped alerts shouldn't show afterwards")
+	}
+	if len(*got) != 1 || (*got)[0].reason != DismissSuppressedByDoNotDisturb {
+		t.Errorf("dismissals = %v, want one suppressed by do not disturb", *got)
+	}
+}
+
+func TestDoNotDisturbKeyToggles(t *testing.T) {
+	m, _ := newTestModel()
+	m = m.WithDoNotDisturbKey("d")
+
+	m = send(m, keyMsg("d"))
+	if !m.IsDoNotDisturb() {
+		t.Fatal("expected the key to turn do not disturb on")
+	}
+	m = send(m, keyMsg("d"))
+	if m.IsDoNotDisturb() {
+		t.Error("expected the key to turn do not disturb off")
+	}
+}
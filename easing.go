@@ -0,0 +1,29 @@
+package bubbleup
+
+// EaseLinear is the easing for WithEasing that blends alerts in at a constant
+// rate. This is the default.
+func EaseLinear(t float64) float64 {
+	return t
+}
+
+// EaseOut is the easing for WithEasing that starts quickly and slows down as
+// the alert settles.
+func EaseOut(t float64) float64 {
+	return 1 - (1-t)*(1-t)
+}
+
+// EaseInOut is the easing for WithEasing that starts and ends slowly, moving
+// fastest in the middle.
+func EaseInOut(t float64) float64 {
+	return t * t * (3 - 2*t)
+}
+
+// WithEasing returns a new AlertModel whose alerts fade in along the curve
+// fn, such as EaseOut or EaseInOut, instead of linearly. fn is given the
+// fade-in's progress from 0 to 1 and returns how far the alert's colors have
+// blended in from the background; it should return 0 at 0 and 1 at 1.
+// A nil fn restores the default. This is an immutable operation.
+func (m AlertModel) WithEasing(fn func(t float64) float64) AlertModel {
+	m.easing = fn
+	return m
+}
@@ -0,0 +1,72 @@
+package bubbleup
+
+import "github.com/muesli/reflow/ansi"
+
+// WithEdgeInset returns a new AlertModel that keeps bordered alerts cells
+// away from the edges of content that draws its own border there, so the
+// two borders don't merge into one. Content counts as bordered at the
+// alert's corner when the character there is a box-drawing glyph or "+".
+// The inset adds to WithVerticalOffset and WithHorizontalOffset, and bare
+// and pill alerts and unbordered content are unaffected. This is an
+// immutable operation.
+func (m AlertModel) WithEdgeInset(cells int) AlertModel {
+	m.edgeInset = max(cells, 0)
+	return m
+}
+
+// borderInset returns the WithEdgeInset the active alert is drawn in by
+// over contentSplit: the inset when both the alert and the content at its
+// corner are bordered, or 0.
+func (m AlertModel) borderInset(contentSplit []string) int {
+	if m.edgeInset == 0 || m.activeAlert.kind == KindBare || m.activeAlert.kind == KindPill || len(contentSplit) == 0 {
+		return 0
+	}
+
+	line := contentSplit[0]
+	right := false
+	switch m.activeAlert.position {
+	case BottomLeftPosition, BottomCenterPosition:
+		line = contentSplit[len(contentSplit)-1]
+	case BottomRightPosition:
+		line, right = contentSplit[len(contentSplit)-1], true
+	case TopRightPosition:
+		right = true
+	}
+
+	if !isBorderRune(edgeRune(line, right)) {
+		return 0
+	}
+	return m.edgeInset
+}
+
+// edgeRune returns the last printable rune of line when last is set, or the
+// first one otherwise, skipping ANSI escape sequences. It returns 0 for a
+// line with nothing printable.
+func edgeRune(line string, last bool) rune {
+	var (
+		edge   rune
+		isAnsi bool
+	)
+	for _, c := range line {
+		switch {
+		case c == ansi.Marker:
+			isAnsi = true
+		case isAnsi:
+			if ansi.IsTerminator(c) {
+				isAnsi = false
+			}
+		default:
+			if !last {
+				return c
+			}
+			edge = c
+		}
+	}
+	return edge
+}
+
+// isBorderRune reports whether c is a box-drawing or block glyph, as used by
+// lipgloss borders, or the "+" corner of an ASCII border.
+func isBorderRune(c rune) bool {
+	return c == '+' || (c >= '\u2500' && c <= '\u259f')
+}
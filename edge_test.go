@@ -0,0 +1,28 @@
+package bubbleup
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestEdgeInsetKeepsAlertOffBorderedContent(t *testing.T) {
+	content := lipgloss.NewStyle().Border(lipgloss.NormalBorder()).Width(38).Height(6).Render("")
+	m, _ := newTestModel()
+	m = raise(m.WithPosition(TopLeftPosition).WithEdgeInset(1), InfoKey, "inset")
+
+	lines := strings.Split(stripANSI(m.Render(content)), "\n")
+	if !strings.HasPrefix(lines[0], "┌──") {
+		t.Errorf("line 0 = %q, want the app's border left alone", lines[0])
+	}
+	if !strings.HasPrefix(lines[1], "│╭──") {
+		t.Errorf("line 1 = %q, want the alert one cell inside the border", lines[1])
+	}
+
+	// Unbordered content isn't inset
+	lines = strings.Split(stripANSI(m.Render(blank(40, 8))), "\n")
+	if !strings.HasPrefix(lines[0], "╭──") {
+		t.Errorf("line 0 = %q, want the alert at the corner of plain content", lines[0])
+	}
+}
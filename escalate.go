@@ -0,0 +1,37 @@
+package bubbleup
+
+// WithEscalation returns a new AlertModel that escalates an alert to the
+// toKey alert type once it has been raised more than afterCount times in a
+// row, e.g. turning a repeated warning into an error. Repeats are counted as
+// they are for repeat badges, using the WithDedupKey key when set. The alert
+// takes the color, prefix and kind of the toKey definition and reports toKey
+// as its type from then on. This is an immutable operation.
+func (m AlertModel) WithEscalation(afterCount int, toKey string) AlertModel {
+	m.escalateAfter = afterCount
+	m.escalateTo = toKey
+	return m
+}
+
+// escalate switches the active alert to the WithEscalation alert type once
+// its repeat count passes the threshold.
+func (m *AlertModel) escalate() {
+	n := m.activeAlert
+	if m.escalateAfter <= 0 || n == nil || n.count <= m.escalateAfter || n.key == m.escalateTo {
+		return
+	}
+
+	// Take the styling from a fresh alert of the escalated type
+	e := m.newAlert(alertMsg{alertKey: m.escalateTo, msg: n.message, font: n.font})
+	if e == nil {
+		return
+	}
+	n.escalatedFrom = n.key
+	n.key = e.key
+	n.prefix = e.prefix
+	n.foreColor = e.foreColor
+	n.iconColor = e.iconColor
+	n.textColor = e.textColor
+	n.borderColor = e.borderColor
+	n.style = e.style
+	n.kind = e.kind
+}
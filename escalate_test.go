@@ -0,0 +1,29 @@
+package bubbleup
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEscalationAfterRepeats(t *testing.T) {
+	m, _ := newTestModel()
+	m = m.WithEscalation(2, ErrorKey)
+	errorAlert := raise(m, ErrorKey, "disk full").activeAlert
+
+	m = raise(raise(m, WarnKey, "disk full"), WarnKey, "disk full")
+	if m.activeAlert.key != WarnKey {
+		t.Fatalf("key = %s after 2 raises, want %s", m.activeAlert.key, WarnKey)
+	}
+
+	m = raise(m, WarnKey, "disk full")
+	n := m.activeAlert
+	if n.key != ErrorKey || n.escalatedFrom != WarnKey {
+		t.Errorf("key = %s from %s after 3 raises, want %s from %s", n.key, n.escalatedFrom, ErrorKey, WarnKey)
+	}
+	if n.borderColor != errorAlert.borderColor || n.iconColor != errorAlert.iconColor {
+		t.Error("expected the escalated alert to take the Error colors")
+	}
+	if out := stripANSI(n.render()); !strings.Contains(out, "[!!]") {
+		t.Errorf("expected the Error prefix, got:\n%s", out)
+	}
+}
@@ -0,0 +1,83 @@
+package bubbleup
+
+import "time"
+
+// AlertEventKind is the lifecycle transition an AlertEvent reports.
+type AlertEventKind int
+
+const (
+	// AlertCreated means an alert was raised and reached Update. It may
+	// still be dropped or held back before it shows.
+	AlertCreated AlertEventKind = iota
+
+	// AlertShown means an alert became the active alert.
+	AlertShown
+
+	// AlertUpdated means the active alert changed in place, e.g. a repeat,
+	// status change, append or badge count.
+	AlertUpdated
+
+	// AlertDismissed means an alert went away or was never shown, for the
+	// AlertEvent's Reason, as reported to the OnDismiss hook.
+	AlertDismissed
+)
+
+func (k AlertEventKind) String() string {
+	switch k {
+	case AlertCreated:
+		return "created"
+	case AlertShown:
+		return "shown"
+	case AlertUpdated:
+		return "updated"
+	case AlertDismissed:
+		return "dismissed"
+	default:
+		return "unknown"
+	}
+}
+
+// AlertEvent describes a lifecycle transition of an alert, as published to
+// the WithEventSink channel.
+type AlertEvent struct {
+	// Kind of transition
+	Kind AlertEventKind
+
+	// The alert's spec at the time of the transition
+	Spec AlertSpec
+
+	// Why the alert went away, for AlertDismissed events only
+	Reason DismissReason
+
+	// When the transition happened, by the model's clock
+	Time time.Time
+}
+
+// WithEventSink returns a new AlertModel that publishes every alert lifecycle
+// transition to ch, e.g. for an app's event bus or audit log. Sends never
+// block: events are dropped while ch is full, so give it a buffer and drain
+// it from another goroutine. This is an immutable operation.
+func (m AlertModel) WithEventSink(ch chan<- AlertEvent) AlertModel {
+	m.eventSink = ch
+	return m
+}
+
+// publish sends an event to the WithEventSink channel, dropping it if the
+// channel is full.
+func (m AlertModel) publish(kind AlertEventKind, spec AlertSpec, reason DismissReason) {
+	if m.eventSink == nil {
+		return
+	}
+	select {
+	case m.eventSink <- AlertEvent{Kind: kind, Spec: spec, Reason: reason, Time: m.getClock().Now()}:
+	default:
+	}
+}
+
+// publishActive publishes an event about the active alert.
+func (m AlertModel) publishActive(kind AlertEventKind) {
+	if m.activeAlert == nil {
+		return
+	}
+	m.publish(kind, m.activeAlert.spec(), 0)
+}
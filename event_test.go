@@ -0,0 +1,97 @@
+package bubbleup
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// drain returns the events waiting in ch as "kind message" strings, with the
+// reason for dismissals.
+func drain(ch chan AlertEvent) []string {
+	var got []string
+	for {
+		select {
+		case e := <-ch:
+			s := fmt.Sprintf("%s %s", e.Kind, e.Spec.Message)
+			if e.Kind == AlertDismissed {
+				s += " (" + e.Reason.String() + ")"
+			}
+			got = append(got, s)
+		default:
+			return got
+		}
+	}
+}
+
+func TestEventSinkPublishesLifecycle(t *testing.T) {
+	ch := make(chan AlertEvent, 16)
+	m, clock := newTestModel()
+	m = m.WithEventSink(ch)
+
+	m = raise(m, InfoKey, "first")
+	m = raise(m, InfoKey, "first")
+	m = raise(m, WarnKey, "second")
+	clock.advance(11 * time.Second)
+	send(m, struct{}{})
+
+	want := []string{
+		"created first", "shown first",
+		"created first", "updated first",
+		"created second", "dismissed first (replaced)", "shown second",
+		"dismissed second (expired)",
+	}
+	if got := drain(ch); !slices.Equal(got, want) {
+		t.Errorf("events = %q, want %q", got, want)
+	}
+}
+
+func TestEventSinkDropsWhenFull(t *testing.T) {
+	ch := make(chan AlertEvent, 1)
+	m, _ := newTestModel()
+	m = raise(raise(m.WithEventSink(ch), InfoKey, "one"), InfoKey, "two")
+
+	if got := drain(ch); len(got) != 1 || got[0] != "created one" {
+		t.Errorf("events = %q, want only the first to fit", got)
+	}
+	if m.activeAlert.message != "two" {
+		t.Error("expected a full sink not to hold up the model")
+	}
+}
+
+func TestEventSinkCreatesOncePerAlert(t *testing.T) {
+	ch := make(chan AlertEvent, 16)
+	m, clock := newTestModel()
+	m = m.WithEventSink(ch).WithDoNotDisturbMode(DoNotDisturbQueue)
+
+	// Released by do not disturb
+	m, _ = m.SetDoNotDisturb(true)
+	m = raise(m, InfoKey, "held")
+	m, cmd := m.SetDoNotDisturb(false)
+	m = send(m, runCmd(cmd)...)
+
+	// Woken from a snooze
+	m = send(m, m.NewAlertCmdWithID("standup", WarnKey, "snoozed")())
+	m = send(m, m.SnoozeCmd("standup", time.Minute)())
+	clock.advance(time.Minute)
+	m = send(m, struct{}{})
+
+	// Expanded from the inbox
+	m = raise(m.WithInboxBadge(TopRightPosition), ErrorKey, "inboxed")
+	out, cmd := m.Update(keyMsg("ctrl+e"))
+	send(out.(AlertModel), runCmd(cmd)...)
+
+	created := map[string]int{}
+	for _, e := range drain(ch) {
+		if message, ok := strings.CutPrefix(e, "created "); ok {
+			created[message]++
+		}
+	}
+	for _, message := range []string{"held", "snoozed", "inboxed"} {
+		if created[message] != 1 {
+			t.Errorf("%q created %d times, want once", message, created[message])
+		}
+	}
+}
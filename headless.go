@@ -0,0 +1,21 @@
+package bubbleup
+
+// WithHeadless returns a new AlertModel that never draws anything: Render
+// returns the content unchanged. Alerts still come and go as usual, with
+// timers, repeats, sequences and hooks all working, so an app can read the
+// shown alert from ActiveAlert and draw it its own way.
+// This is an immutable operation.
+func (m AlertModel) WithHeadless() AlertModel {
+	m.headless = true
+	return m
+}
+
+// ActiveAlert returns the spec of the alert that's shown, with its current
+// message, and whether there is one, e.g. for drawing alerts yourself with
+// WithHeadless.
+func (m AlertModel) ActiveAlert() (AlertSpec, bool) {
+	if !m.shown() {
+		return AlertSpec{}, false
+	}
+	return m.activeAlert.spec(), true
+}
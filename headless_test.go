@@ -0,0 +1,33 @@
+package bubbleup
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHeadlessRendersNothingButTracksAlert(t *testing.T) {
+	m, clock := newTestModel()
+	m = m.WithHeadless()
+	if _, ok := m.ActiveAlert(); ok {
+		t.Error("expected no active alert yet")
+	}
+
+	m = send(m, m.NewAlertCmdWithID("build", ErrorKey, "build failed")())
+	content := blank(30, 6)
+	if got := m.Render(content); got != content {
+		t.Errorf("Render() = %q, want the content unchanged", got)
+	}
+	spec, ok := m.ActiveAlert()
+	if !ok || spec.ID != "build" || spec.Key != ErrorKey || spec.Message != "build failed" {
+		t.Errorf("ActiveAlert() = %+v, %v, want the build failure", spec, ok)
+	}
+
+	// Timers still run
+	clock.advance(11 * time.Second)
+	if m = send(m, struct{}{}); m.HasActiveAlert() {
+		t.Error("expected the alert to expire while headless")
+	}
+	if _, ok := m.ActiveAlert(); ok {
+		t.Error("expected no active alert once expired")
+	}
+}
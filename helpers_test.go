@@ -0,0 +1,123 @@
+package bubbleup
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// fakeClock is a Clock that only moves when advanced. Ticks fire straight
+// away with the clock's time when their command is run.
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) Tick(_ time.Duration, fn func(time.Time) tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		return fn(c.now)
+	}
+}
+
+func (c *fakeClock) advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+// newTestModel returns a model in test mode on a fake clock, 20 columns wide
+// with ASCII prefixes and alerts lasting 10 seconds.
+func newTestModel() (AlertModel, *fakeClock) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	m := NewAlertModel(20, false, 10).WithTestMode().WithClock(clock)
+	return m, clock
+}
+
+// send passes each msg to m's Update in turn, dropping the returned commands.
+func send(m AlertModel, msgs ...tea.Msg) AlertModel {
+	for _, msg := range msgs {
+		out, _ := m.Update(msg)
+		m = out.(AlertModel)
+	}
+	return m
+}
+
+// runCmd runs cmd and any commands it batches, returning the messages they
+// produce.
+func runCmd(cmd tea.Cmd) []tea.Msg {
+	if cmd == nil {
+		return nil
+	}
+	switch msg := cmd().(type) {
+	case nil:
+		return nil
+	case tea.BatchMsg:
+		var msgs []tea.Msg
+		for _, c := range msg {
+			msgs = append(msgs, runCmd(c)...)
+		}
+		return msgs
+	default:
+		return []tea.Msg{msg}
+	}
+}
+
+// raise shows an alert of type key with message on m.
+func raise(m AlertModel, key, message string) AlertModel {
+	return send(m, m.NewAlertCmd(key, message)())
+}
+
+// keyMsg returns the tea.KeyMsg for key as tea names it, e.g. "esc",
+// "ctrl+e" or "x".
+func keyMsg(key string) tea.KeyMsg {
+	for t, name := range map[tea.KeyType]string{
+		tea.KeyEsc: "esc", tea.KeyEnter: "enter", tea.KeyTab: "tab",
+		tea.KeyLeft: "left", tea.KeyRight: "right", tea.KeyCtrlE: "ctrl+e",
+		tea.KeyCtrlX: "ctrl+x",
+	} {
+		if name == key {
+			return tea.KeyMsg{Type: t}
+		}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+}
+
+// withTrueColor renders with true color for the rest of the test, since
+// tests otherwise render without color.
+func withTrueColor(t *testing.T) {
+	profile := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(termenv.TrueColor)
+	t.Cleanup(func() {
+		lipgloss.SetColorProfile(profile)
+	})
+}
+
+// blank returns height lines of width spaces.
+func blank(width, height int) string {
+	lines := make([]string, height)
+	for i := range lines {
+		lines[i] = strings.Repeat(" ", width)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// assertContains fails the test unless s contains want.
+func assertContains(t *testing.T, s, want string) {
+	t.Helper()
+	if !strings.Contains(s, want) {
+		t.Errorf("expected %q in:\n%s", want, s)
+	}
+}
+
+// assertNotContains fails the test if s contains unwanted.
+func assertNotContains(t *testing.T, s, unwanted string) {
+	t.Helper()
+	if strings.Contains(s, unwanted) {
+		t.Errorf("unexpected %q in:\n%s", unwanted, s)
+	}
+}
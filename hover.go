@@ -0,0 +1,63 @@
+package bubbleup
+
+import (
+	"os"
+	"strconv"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// WithPauseOnHover returns a new AlertModel that pauses the active alert's
+// timer while the mouse is over it, resuming once it moves away, like
+// desktop toasts. Bounds are worked out assuming Render is given content
+// filling the terminal, so tea.WindowSizeMsg must reach Update. The program
+// must report mouse motion, see tea.WithMouseAllMotion.
+// This is an immutable operation.
+func (m AlertModel) WithPauseOnHover() AlertModel {
+	m.pauseOnHover = true
+	return m
+}
+
+// updateHover pauses or resumes the active alert's timer as the mouse
+// enters or leaves it.
+func (m AlertModel) updateHover(msg tea.MouseMsg) {
+	if !m.pauseOnHover || m.activeAlert == nil {
+		return
+	}
+
+	n := m.activeAlert
+	hovering := m.alertContains(msg.X, msg.Y)
+	switch {
+	case hovering && n.hoveredAt.IsZero():
+		n.hoveredAt = m.getClock().Now()
+	case !hovering && !n.hoveredAt.IsZero():
+		// Resume with the time that was left when the hover began
+		n.deathTime = n.deathTime.Add(m.getClock().Now().Sub(n.hoveredAt))
+		n.hoveredAt = time.Time{}
+	}
+}
+
+// alertContains reports whether the terminal cell at (x, y) lies on the
+// active alert, as placed over content filling the terminal or in the
+// WithSidebar gutter.
+func (m AlertModel) alertContains(x, y int) bool {
+	lines, width := getLines(m.renderActiveAlert())
+	height := len(lines)
+	left, top := m.alertOrigin(width, height, m.terminalWidth(), m.terminalHeight())
+	return x >= left && x < left+width && y >= top && y < top+height
+}
+
+// terminalHeight returns the terminal height from the last tea.WindowSizeMsg,
+// falling back to the LINES environment variable before one arrives.
+// It returns 0 when the height is unknown.
+func (m AlertModel) terminalHeight() int {
+	if m.termHeight > 0 {
+		return m.termHeight
+	}
+	lines, err := strconv.Atoi(os.Getenv("LINES"))
+	if err != nil || lines < 0 {
+		return 0
+	}
+	return lines
+}
@@ -0,0 +1,42 @@
+package bubbleup
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// mouseAt returns mouse motion to the cell (x, y).
+func mouseAt(x, y int) tea.MouseMsg {
+	return tea.MouseMsg{X: x, Y: y, Action: tea.MouseActionMotion, Button: tea.MouseButtonNone}
+}
+
+func TestPauseOnHoverOnlyWhileHovering(t *testing.T) {
+	m, clock := newTestModel()
+	m = m.WithPauseOnHover().WithPosition(TopLeftPosition)
+	m = send(m, tea.WindowSizeMsg{Width: 40, Height: 10})
+	m = raise(m, InfoKey, "hover me")
+
+	// Moving elsewhere doesn't pause
+	clock.advance(2 * time.Second)
+	m = send(m, mouseAt(30, 8))
+
+	// Hovering holds the alert well past its 10 seconds
+	m = send(m, mouseAt(2, 1))
+	clock.advance(30 * time.Second)
+	if m = send(m, struct{}{}); m.activeAlert == nil {
+		t.Fatal("expected the alert to stay while hovered")
+	}
+
+	// Leaving resumes with the 8 seconds that were left
+	m = send(m, mouseAt(30, 8))
+	clock.advance(7 * time.Second)
+	if m = send(m, struct{}{}); m.activeAlert == nil {
+		t.Fatal("expected the alert to resume with its remaining time")
+	}
+	clock.advance(2 * time.Second)
+	if m = send(m, struct{}{}); m.activeAlert != nil {
+		t.Error("expected the alert to expire once its remaining time ran out")
+	}
+}
@@ -0,0 +1,28 @@
+package bubbleup
+
+import "time"
+
+// WithDismissOnIdle returns a new AlertModel whose alerts stay up while the
+// user is active and only start their usual countdown once no key press or
+// mouse event has reached Update for the idle window. Any activity after that
+// holds the alert again for another idle window. This is the inverse of
+// WithPauseOnHover, for alerts the user should have a quiet moment to read.
+// This is an immutable operation.
+func (m AlertModel) WithDismissOnIdle(idle time.Duration) AlertModel {
+	m.dismissOnIdle = idle
+	return m
+}
+
+// deferForActivity restarts the WithDismissOnIdle wait before the active
+// alert's countdown, as the user has just been active.
+func (m AlertModel) deferForActivity() {
+	n := m.activeAlert
+	if m.dismissOnIdle <= 0 || n == nil || n.sticky {
+		return
+	}
+
+	deathTime := m.getClock().Now().Add(m.dismissOnIdle + n.dur + n.focusBoost)
+	if deathTime.After(n.deathTime) {
+		n.deathTime = deathTime
+	}
+}
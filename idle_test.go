@@ -0,0 +1,29 @@
+package bubbleup
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDismissOnIdleWaitsForIdleness(t *testing.T) {
+	m, clock := newTestModel()
+	m = raise(m.WithDismissOnIdle(5*time.Second), InfoKey, "read when quiet")
+
+	// Keep typing well past the 10 second duration
+	for range 10 {
+		clock.advance(4 * time.Second)
+		if m = send(m, keyMsg("x")); m.activeAlert == nil {
+			t.Fatalf("alert dismissed at %v despite activity", clock.now.Sub(time.Unix(0, 0)))
+		}
+	}
+
+	// Idle for the 5 second window plus the 10 second countdown
+	clock.advance(14 * time.Second)
+	if m = send(m, struct{}{}); m.activeAlert == nil {
+		t.Fatal("expected the alert to wait out the idle window and its duration")
+	}
+	clock.advance(2 * time.Second)
+	if m = send(m, struct{}{}); m.activeAlert != nil {
+		t.Error("expected the alert to go once the user was idle long enough")
+	}
+}
@@ -0,0 +1,124 @@
+package bubbleup
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// WithInboxBadge returns a new AlertModel that collects incoming alerts
+// instead of showing them, rendering a small unread count such as "🔔 3" at
+// pos. Pressing the expand key (see WithExpandKey) shows the latest alert and
+// clears the count. Status alerts still show as usual. Invalid positions are
+// ignored. This is an immutable operation.
+func (m AlertModel) WithInboxBadge(pos Position) AlertModel {
+	if pos.IsValid() {
+		m.inboxPosition = pos
+	}
+	return m
+}
+
+// WithInboxBadgeStyle returns a new AlertModel that draws the inbox badge
+// with icon in place of the bell, and rendered with style instead of the
+// Info alert color. An empty icon keeps the default for the font mode.
+// This is an immutable operation.
+func (m AlertModel) WithInboxBadgeStyle(icon string, style lipgloss.Style) AlertModel {
+	m.inboxIcon = icon
+	m.inboxStyle = &style
+	return m
+}
+
+// UnreadCount returns how many alerts in the inbox haven't been read yet.
+func (m AlertModel) UnreadCount() int {
+	return len(m.inbox)
+}
+
+// Acknowledge returns a new AlertModel with the inbox alerts raised with id,
+// e.g. via NewAlertCmdWithID, marked as read, so they no longer count as
+// unread. The expand key then shows the latest alert still unread. Alerts
+// without an ID are only marked read along with the rest of the inbox.
+func (m AlertModel) Acknowledge(id AlertID) AlertModel {
+	if id == "" {
+		return m
+	}
+
+	// Copy before writing, since earlier copies of the model share the slice
+	inbox := make([]alertMsg, 0, len(m.inbox))
+	for _, msg := range m.inbox {
+		if msg.id != id {
+			inbox = append(inbox, msg)
+		}
+	}
+	m.inbox = inbox
+	return m
+}
+
+// AcknowledgeInbox returns a new AlertModel with every alert in the inbox
+// marked as read, clearing the unread count without showing the latest one.
+func (m AlertModel) AcknowledgeInbox() AlertModel {
+	m.inbox = nil
+	return m
+}
+
+// collectInInbox reports whether msg went to the inbox rather than being
+// shown.
+func (m *AlertModel) collectInInbox(msg alertMsg) bool {
+	if m.inboxPosition == "" || msg.inboxed || msg.sticky {
+		return false
+	}
+
+	msg.inboxed = true
+	inbox := make([]alertMsg, 0, len(m.inbox)+1)
+	m.inbox = append(append(inbox, m.inbox...), msg)
+	return true
+}
+
+// expandInbox marks the inbox as read and returns the tea.Cmd that shows the
+// latest alert in it.
+func (m *AlertModel) expandInbox() tea.Cmd {
+	latest := m.inbox[len(m.inbox)-1]
+	m.inbox = nil
+	return func() tea.Msg {
+		return latest
+	}
+}
+
+// hasInInbox reports whether an unread alert of type key is in the inbox.
+func (m AlertModel) hasInInbox(key string) bool {
+	for _, msg := range m.inbox {
+		if msg.alertKey == key {
+			return true
+		}
+	}
+	return false
+}
+
+// drawInboxBadge overlays the unread count onto content, if there is one.
+func (m AlertModel) drawInboxBadge(content string) string {
+	if len(m.inbox) == 0 {
+		return content
+	}
+
+	icon := m.inboxIcon
+	switch {
+	case icon != "":
+	case m.useNerdFont || m.useUnicodePrefix:
+		icon = "🔔"
+	default:
+		icon = "(*)"
+	}
+	style := lipgloss.NewStyle().Foreground(lipgloss.Color(InfoColor))
+	if m.inboxStyle != nil {
+		style = *m.inboxStyle
+	}
+	badge := style.Render(fmt.Sprintf("%s %d", icon, len(m.inbox)))
+	badgeWidth := lipgloss.Width(badge)
+
+	lines, contentWidth := getLines(content)
+	y := m.startLineForPosition(m.inboxPosition, 1, len(lines))
+	x := m.columnForPosition(m.inboxPosition, badgeWidth, m.referenceWidth(contentWidth))
+	lines[y] = overlayAt(lines[y], badge, x, badgeWidth)
+	return strings.Join(lines, "\n")
+}
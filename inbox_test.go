@@ -0,0 +1,94 @@
+package bubbleup
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// expand presses the expand key on m and delivers what it asks for next.
+func expand(m AlertModel) AlertModel {
+	out, cmd := m.Update(keyMsg("ctrl+e"))
+	return send(out.(AlertModel), runCmd(cmd)...)
+}
+
+func TestInboxBadgeCountsAndClearsOnExpand(t *testing.T) {
+	m, _ := newTestModel()
+	m = m.WithInboxBadge(TopRightPosition)
+	m = raise(raise(raise(m, InfoKey, "one"), WarnKey, "two"), InfoKey, "three")
+
+	if m.activeAlert != nil {
+		t.Fatal("expected inbox alerts not to show as toasts")
+	}
+	if n := m.UnreadCount(); n != 3 {
+		t.Errorf("UnreadCount() = %d, want 3", n)
+	}
+	first := strings.Split(stripANSI(m.Render(blank(30, 4))), "\n")[0]
+	if !strings.HasSuffix(first, "(*) 3") {
+		t.Errorf("first line = %q, want the badge at the top right", first)
+	}
+
+	m = expand(m)
+	if m.UnreadCount() != 0 {
+		t.Errorf("UnreadCount() = %d after expanding, want 0", m.UnreadCount())
+	}
+	if m.activeAlert == nil || m.activeAlert.message != "three" {
+		t.Fatal("expected expanding to show the latest alert")
+	}
+	assertNotContains(t, stripANSI(m.Render(blank(30, 4))), "(*)")
+}
+
+func TestAcknowledgeInboxClearsWithoutShowing(t *testing.T) {
+	m, _ := newTestModel()
+	m = raise(m.WithInboxBadge(TopRightPosition), InfoKey, "one")
+
+	m = m.AcknowledgeInbox()
+	if m.UnreadCount() != 0 {
+		t.Errorf("UnreadCount() = %d, want 0", m.UnreadCount())
+	}
+	if m = send(m, tea.KeyMsg{Type: tea.KeyCtrlE}); m.activeAlert != nil {
+		t.Error("expected nothing left to expand after acknowledging")
+	}
+}
+
+func TestInboxBadgeUsesConfiguredStyle(t *testing.T) {
+	withTrueColor(t)
+	m, _ := newTestModel()
+	style := lipgloss.NewStyle().Foreground(lipgloss.Color("#00FF00"))
+	m = m.WithInboxBadge(TopLeftPosition).WithInboxBadgeStyle("✉", style)
+	m = raise(raise(m, InfoKey, "one"), InfoKey, "two")
+
+	out := m.Render(blank(30, 3))
+	assertContains(t, out, "\x1b[38;2;0;255;0m✉ 2")
+	assertNotContains(t, stripANSI(out), "(*)")
+}
+
+func TestAcknowledgeMarksOneAlertRead(t *testing.T) {
+	m, _ := newTestModel()
+	m = m.WithInboxBadge(TopRightPosition)
+	m = send(m, m.NewAlertCmdWithID("a", InfoKey, "first")())
+	m = raise(m, InfoKey, "no id")
+	m = send(m, m.NewAlertCmdWithID("b", InfoKey, "latest")())
+
+	before := m
+	if m = m.Acknowledge("b"); m.UnreadCount() != 2 {
+		t.Errorf("UnreadCount() = %d after acknowledging one, want 2", m.UnreadCount())
+	}
+	if before.UnreadCount() != 3 {
+		t.Errorf("expected the earlier model to keep 3 unread, got %d", before.UnreadCount())
+	}
+	if m = m.Acknowledge("missing").Acknowledge(""); m.UnreadCount() != 2 {
+		t.Errorf("UnreadCount() = %d after acknowledging unknown IDs, want 2", m.UnreadCount())
+	}
+	assertContains(t, stripANSI(m.Render(blank(30, 4))), "(*) 2")
+
+	// Expanding shows the latest alert still unread and reads the rest
+	if m = expand(m); m.activeAlert == nil || m.activeAlert.message != "no id" {
+		t.Fatal("expected expanding to show the latest unread alert")
+	}
+	if m.UnreadCount() != 0 {
+		t.Errorf("UnreadCount() = %d after expanding, want 0", m.UnreadCount())
+	}
+}
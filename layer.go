@@ -0,0 +1,41 @@
+package bubbleup
+
+import "strings"
+
+// Rect is an area of the content passed to Render: its top-left cell at X
+// columns and Y lines from the top-left corner, and its size in cells.
+type Rect struct {
+	X, Y          int
+	Width, Height int
+}
+
+// WithTopLayer returns a new AlertModel whose Render draws render's output
+// within bounds after the alerts, so content such as a help overlay is never
+// covered by them. Output beyond bounds is cut off. This is an immutable
+// operation.
+func (m AlertModel) WithTopLayer(render func() string, bounds Rect) AlertModel {
+	m.topLayer = render
+	m.topLayerBounds = bounds
+	return m
+}
+
+// drawTopLayer overlays the WithTopLayer content onto content.
+func (m AlertModel) drawTopLayer(content string) string {
+	if m.topLayer == nil {
+		return content
+	}
+
+	b := m.topLayerBounds
+	if b.X < 0 || b.Y < 0 || b.Width <= 0 || b.Height <= 0 {
+		return content
+	}
+
+	lines := strings.Split(content, "\n")
+	layer := strings.Split(m.topLayer(), "\n")
+	for i := 0; i < b.Height && i < len(layer) && b.Y+i < len(lines); i++ {
+		// Fit the layer line to the bounds, cutting or padding as needed
+		layerLine := padRight(cutRight(layer[i], b.Width), b.Width)
+		lines[b.Y+i] = overlayAt(lines[b.Y+i], layerLine, b.X, b.Width)
+	}
+	return strings.Join(lines, "\n")
+}
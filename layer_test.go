@@ -0,0 +1,25 @@
+package bubbleup
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTopLayerSurvivesOverlappingAlert(t *testing.T) {
+	m, _ := newTestModel()
+	help := func() string { return "HELP\n? keys and more" }
+	m = m.WithPosition(TopLeftPosition).WithTopLayer(help, Rect{X: 4, Y: 1, Width: 8, Height: 2})
+	m = raise(m, InfoKey, "an alert under help")
+
+	lines := strings.Split(stripANSI(m.Render(blank(30, 5))), "\n")
+	for i, want := range []string{"HELP    ", "? keys a"} {
+		if got := string([]rune(lines[i+1])[4:12]); got != want {
+			t.Errorf("line %d = %q, want the top layer's %q", i+1, lines[i+1], want)
+		}
+	}
+
+	// The alert still shows around the layer
+	if !strings.HasPrefix(lines[1], "│ (i") || !strings.HasPrefix(lines[0], "╭") {
+		t.Errorf("expected the alert around the layer, got:\n%s", strings.Join(lines, "\n"))
+	}
+}
@@ -1,11 +1,19 @@
 package bubbleup
 
 import (
+	"fmt"
+	"hash/fnv"
+	"io"
+	"os"
+	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 	"github.com/muesli/reflow/ansi"
+	"golang.org/x/term"
 )
 
 // AlertModel maintains a list of alert types, and facilitates the display and
@@ -15,15 +23,89 @@ import (
 //   - minWidth == 0 (default): width is fixed width
 //   - minWidth > 0: width is max width, minWidth is minimum, actual width varies with message length
 type AlertModel struct {
-	useNerdFont      bool
-	useUnicodePrefix bool
-	allowEscToClose  bool
-	alertTypes       map[string]AlertDefinition
-	activeAlert      *alert
-	width            int
-	minWidth         int
-	duration         time.Duration
-	position         Position
+	useNerdFont       bool
+	useUnicodePrefix  bool
+	allowEscToClose   bool
+	borderMatchesType bool
+	alertTypes        map[string]AlertDefinition
+	activeAlert       *alert
+	width             int
+	minWidth          int
+	textWidth         int
+	lineLimit         int
+	expandKey         string
+	dismissAllKey     string
+	compactBelowWidth int
+	termWidth         int
+	termHeight        int
+	pauseOnHover      bool
+	inboxPosition     Position
+	inbox             []alertMsg
+	inboxIcon         string
+	inboxStyle        *lipgloss.Style
+	border            *lipgloss.Border
+	testMode          bool
+	errorCause        bool
+	topLayer          func() string
+	topLayerBounds    Rect
+	ellipsis          string
+	doNotDisturb      bool
+	dndMode           DoNotDisturbMode
+	dndKey            string
+	heldAlert         *alertMsg
+	blurred           bool
+	refreshOnAppend   bool
+	escConsumed       bool
+	maxLifetime       time.Duration
+	tickID            int64
+	dedupKey          func(key, message string) string
+	flashTimes        int
+	flashKeys         map[string]bool
+	extendOnFocus     time.Duration
+	rawMessages       bool
+	keepWhitespace    bool
+	maxMessageLength  int
+	verticalOffset    int
+	horizontalOffset  int
+	idCollision       IDCollisionPolicy
+	suppressWithin    time.Duration
+	lastShown         map[string]time.Time
+	escalateAfter     int
+	escalateTo        string
+	sidebarWidth      int
+	sidebarSide       lipgloss.Position
+	minContrast       float64
+	dismissOnIdle     time.Duration
+	snoozed           []snoozedAlert
+	durationBase      time.Duration
+	durationPerRune   time.Duration
+	durationCap       time.Duration
+	edgeInset         int
+	eventSink         chan<- AlertEvent
+	timerMode         TimerMode
+	terminalTitle     bool
+	title             string
+	titleRestore      string
+	titleKeys         map[string]bool
+	confirmDismiss    bool
+	confirmKeys       map[string]bool
+	paused            bool
+	pausedAt          time.Time
+	reservedHeight    int
+	easing            func(t float64) float64
+	tickerInterval    time.Duration
+	ticker            []*alert
+	headless          bool
+	positionReference PositionReference
+	rotatedAt         time.Time
+	sequence          []alertMsg
+	duration          time.Duration
+	position          Position
+	clock             Clock
+	fallbackWriter    io.Writer
+	sounder           Sounder
+	soundKeys         map[string]bool
+	onDismiss         func(spec AlertSpec, reason DismissReason)
 }
 
 // TODO: Set defaults for duration
@@ -34,10 +116,13 @@ func NewAlertModel(width int, useNerdFont bool, duration time.Duration) *AlertMo
 		activeAlert: nil,
 		width:       width,
 		minWidth:    0,
+		expandKey:   DefaultExpandKey,
+		ellipsis:    DefaultEllipsis,
 		useNerdFont: useNerdFont,
 		alertTypes:  make(map[string]AlertDefinition),
 		duration:    duration,
-		position:    TopLeftPosition,
+		position:    defaultPosition,
+		clock:       systemClock{},
 	}
 
 	model.registerDefaultAlertTypes()
@@ -52,6 +137,32 @@ func (m AlertModel) WithPosition(pos Position) AlertModel {
 	return m
 }
 
+// WithVerticalOffset returns a new AlertModel that moves alerts rows lines
+// away from the top or bottom edge they are anchored to, e.g. to clear a
+// fixed header or footer. Alerts are kept on screen. This is an immutable
+// operation.
+func (m AlertModel) WithVerticalOffset(rows int) AlertModel {
+	m.verticalOffset = max(rows, 0)
+	return m
+}
+
+// WithHorizontalOffset returns a new AlertModel that moves left and right
+// aligned alerts cols columns away from their edge. Centered alerts stay
+// centered. Alerts are kept on screen. This is an immutable operation.
+func (m AlertModel) WithHorizontalOffset(cols int) AlertModel {
+	m.horizontalOffset = max(cols, 0)
+	return m
+}
+
+// WithWidth returns a new AlertModel with a new fixed or max width, e.g.
+// after the terminal is resized. A minWidth above width is clamped to it.
+// The active alert re-wraps to fit. This is an immutable operation.
+func (m AlertModel) WithWidth(width int) AlertModel {
+	m.width = width
+	m.minWidth = min(m.minWidth, width)
+	return m.Reflow()
+}
+
 // WithMinWidth returns a new AlertModel with dynamic width enabled.
 // When minWidth > 0, the notification width will vary between minWidth and width (max)
 // based on the actual message length. This is an immutable operation.
@@ -61,19 +172,317 @@ func (m AlertModel) WithMinWidth(min int) AlertModel {
 		min = m.width // clamp to max
 	}
 	m.minWidth = min
+	return m.Reflow()
+}
+
+// WithErrorCause returns a new AlertModel whose NewErrorAlertCmd alerts show
+// the root cause of a wrapped error on its own line. This is an immutable
+// operation.
+func (m AlertModel) WithErrorCause() AlertModel {
+	m.errorCause = true
+	return m
+}
+
+// WithRawMessages returns a new AlertModel that shows messages exactly as
+// given. By default, control characters and escape sequences other than
+// colors and styling are stripped so they can't corrupt the overlay.
+// This is an immutable operation.
+func (m AlertModel) WithRawMessages() AlertModel {
+	m.rawMessages = true
+	return m
+}
+
+// WithTrimMessages returns a new AlertModel that trims leading and trailing
+// whitespace from each line of a message when trim is true, the default, so
+// stray spaces don't throw off sizing and centering. Pass false to keep
+// intentional indentation. This is an immutable operation.
+func (m AlertModel) WithTrimMessages(trim bool) AlertModel {
+	m.keepWhitespace = !trim
+	return m
+}
+
+// WithMaxMessageLength returns a new AlertModel that cuts messages longer
+// than n runes, ending them with the ellipsis, before they are wrapped. This
+// guards the layout against pathological input; it applies to each message
+// and each appended line. 0 turns the limit off. This is an immutable
+// operation.
+func (m AlertModel) WithMaxMessageLength(n int) AlertModel {
+	m.maxMessageLength = max(n, 0)
+	return m
+}
+
+// cleanMessage sanitizes s unless WithRawMessages is set, trims each line
+// unless WithTrimMessages is off, then applies WithMaxMessageLength.
+func (m AlertModel) cleanMessage(s string) string {
+	if !m.rawMessages {
+		s = sanitize(s)
+	}
+	if !m.keepWhitespace {
+		lines := strings.Split(s, "\n")
+		for i, line := range lines {
+			lines[i] = strings.TrimSpace(line)
+		}
+		s = strings.Join(lines, "\n")
+	}
+	if m.maxMessageLength > 0 {
+		s = truncateRunes(s, m.maxMessageLength, m.getEllipsis())
+	}
+	return s
+}
+
+// SizePreset is a ready-made pair of min and max alert widths, set with
+// WithSizePreset.
+type SizePreset int
+
+const (
+	SizeSmall  SizePreset = iota // 20 to 40 columns
+	SizeMedium                   // 30 to 60 columns
+	SizeLarge                    // 40 to 80 columns
+)
+
+// WithSizePreset returns a new AlertModel with dynamic width between the
+// preset's min and max widths, replacing the width given to NewAlertModel.
+// Unknown presets leave the widths unchanged. This is an immutable operation.
+func (m AlertModel) WithSizePreset(preset SizePreset) AlertModel {
+	switch preset {
+	case SizeSmall:
+		m.minWidth, m.width = 20, 40
+	case SizeMedium:
+		m.minWidth, m.width = 30, 60
+	case SizeLarge:
+		m.minWidth, m.width = 40, 80
+	}
+	return m.Reflow()
+}
+
+// WithTextWidth returns a new AlertModel that wraps message text at n
+// columns, independent of the box width derived from width and minWidth.
+// The box still pads out to its own width. Text never wraps wider than the
+// box's content area; 0 restores wrapping at the full content width.
+// This is an immutable operation.
+func (m AlertModel) WithTextWidth(n int) AlertModel {
+	if n < 0 {
+		n = 0
+	}
+	m.textWidth = n
+	return m.Reflow()
+}
+
+// WithLineLimit returns a new AlertModel that caps alerts at n wrapped lines.
+// Longer messages show their first n-1 lines followed by a "… (+K lines)"
+// indicator, and pressing the expand key shows the full text inline.
+// A limit of 0 disables truncation; a limit of 1 is treated as 2 so at least
+// one line of the message stays visible. This is an immutable operation.
+func (m AlertModel) WithLineLimit(n int) AlertModel {
+	if n < 0 {
+		n = 0
+	}
+	if n == 1 {
+		n = 2
+	}
+	m.lineLimit = n
+	return m.Reflow()
+}
+
+// WithExpandKey returns a new AlertModel that uses key to expand an alert
+// truncated by WithLineLimit. The key is left alone while the shown alert
+// fits within the limit. Defaults to DefaultExpandKey.
+func (m AlertModel) WithExpandKey(key string) AlertModel {
+	m.expandKey = key
+	return m
+}
+
+// WithEllipsis returns a new AlertModel that marks truncated text with s
+// instead of DefaultEllipsis, e.g. "..." for ASCII-only terminals. It applies
+// to compact alerts and line-limit indicators alike. This is an immutable
+// operation.
+func (m AlertModel) WithEllipsis(s string) AlertModel {
+	m.ellipsis = s
+	return m.Reflow()
+}
+
+// WithBorder returns a new AlertModel that frames alerts with the characters
+// of border instead of the default rounded box, e.g. ASCIIBorder on
+// terminals that garble box-drawing glyphs. This is an immutable operation.
+func (m AlertModel) WithBorder(border lipgloss.Border) AlertModel {
+	m.border = &border
+	return m.Reflow()
+}
+
+// getEllipsis returns the model's ellipsis, falling back to DefaultEllipsis
+// for models that weren't created via NewAlertModel.
+func (m AlertModel) getEllipsis() string {
+	if m.ellipsis == "" {
+		return DefaultEllipsis
+	}
+	return m.ellipsis
+}
+
+// WithRefreshOnAppend returns a new AlertModel where appending to an alert
+// with AppendToAlertCmd restarts the alert's timer, so a live log stays up
+// while lines keep arriving. This is an immutable operation.
+func (m AlertModel) WithRefreshOnAppend() AlertModel {
+	m.refreshOnAppend = true
+	return m
+}
+
+// WithMaxLifetime returns a new AlertModel where no alert stays on screen
+// longer than d after it first appears, even if repeats or appends keep
+// restarting its timer. Pinned status alerts are exempt. Unlike the model's
+// duration, d is a regular time.Duration, e.g. 30*time.Second. 0 disables
+// the ceiling. This is an immutable operation.
+func (m AlertModel) WithMaxLifetime(d time.Duration) AlertModel {
+	m.maxLifetime = d
+	return m
+}
+
+// WithDurationPerChar returns a new AlertModel where alerts show for base plus
+// perRune for every visible character of their message, so longer messages
+// get more reading time, e.g. 2*time.Second and 50*time.Millisecond. It
+// replaces the model's duration, but an alert type's or spec's own duration
+// still wins. See WithDurationCap to bound it. Like WithMaxLifetime, both are
+// regular time.Durations. This is an immutable operation.
+func (m AlertModel) WithDurationPerChar(base, perRune time.Duration) AlertModel {
+	m.durationBase = base
+	m.durationPerRune = perRune
+	return m
+}
+
+// WithDurationCap returns a new AlertModel where durations worked out by
+// WithDurationPerChar never exceed max, so very long messages don't stay up
+// indefinitely. 0 removes the cap. This is an immutable operation.
+func (m AlertModel) WithDurationCap(max time.Duration) AlertModel {
+	m.durationCap = max
+	return m
+}
+
+// WithDedupKey returns a new AlertModel that treats an incoming alert as a
+// repeat of the active one when fn returns the same key for both, for example
+// after stripping ids or counts from templated messages. Repeats coalesce into
+// the active alert, showing the newest message and bumping its badge.
+// By default alerts repeat when their type and message match exactly.
+// This is an immutable operation.
+func (m AlertModel) WithDedupKey(fn func(key, message string) string) AlertModel {
+	m.dedupKey = fn
+	return m
+}
+
+// WithFlash returns a new AlertModel where alerts of the given type keys
+// flash brightly times times when they appear, then settle, to grab attention
+// for critical errors. With no keys, every alert type flashes. Each flash
+// lasts one animation tick. On terminals without color support the flash
+// has no visible effect. This is an immutable operation.
+func (m AlertModel) WithFlash(times int, keys ...string) AlertModel {
+	m.flashTimes = max(times, 0)
+	m.flashKeys = nil
+	if len(keys) > 0 {
+		m.flashKeys = make(map[string]bool, len(keys))
+		for _, key := range keys {
+			m.flashKeys[key] = true
+		}
+	}
+	return m
+}
+
+// flashPhases returns how many bright and dim ticks a new alert of type key
+// flashes for.
+func (m AlertModel) flashPhases(key string) int {
+	if m.flashKeys != nil && !m.flashKeys[key] {
+		return 0
+	}
+	return m.flashTimes * 2
+}
+
+// WithExtendOnFocus returns a new AlertModel that gives the active alert d
+// more time when the terminal regains focus, so a user returning to the app
+// can still read it. Losing focus again takes the extra time back, restoring
+// the original countdown. Like WithMaxLifetime, d is a regular time.Duration.
+// Focus is only reported when the program is run with tea.WithReportFocus.
+// This is an immutable operation.
+func (m AlertModel) WithExtendOnFocus(d time.Duration) AlertModel {
+	m.extendOnFocus = d
 	return m
 }
 
 // WithUnicodePrefix switches the AlertModule to use Unicode fonts
 func (m AlertModel) WithUnicodePrefix() AlertModel {
-	m.useNerdFont = false
-	m.useUnicodePrefix = true //
-	alertTypes := make(map[string]AlertDefinition, len(m.alertTypes))
-	for name, alertType := range m.alertTypes {
-		alertType.Prefix = unicodePrefixes[alertType.Key]
-		alertTypes[name] = alertType
-	}
-	m.alertTypes = alertTypes
+	return m.SetFontMode(FontUnicode)
+}
+
+// FontMode returns the font mode used for alert prefixes: FontNerd,
+// FontUnicode or FontASCII.
+func (m AlertModel) FontMode() string {
+	switch {
+	case m.useNerdFont:
+		return FontNerd
+	case m.useUnicodePrefix:
+		return FontUnicode
+	default:
+		return FontASCII
+	}
+}
+
+// SetFontMode returns a new AlertModel that uses mode, one of FontNerd,
+// FontUnicode or FontASCII, for alert prefixes, e.g. to switch fonts at
+// runtime without rebuilding the model. The active alert picks up its new
+// prefix on the next render, see Reflow. Unknown modes are ignored.
+func (m AlertModel) SetFontMode(mode string) AlertModel {
+	switch mode {
+	case FontNerd:
+		m.useNerdFont, m.useUnicodePrefix = true, false
+	case FontUnicode:
+		m.useNerdFont, m.useUnicodePrefix = false, true
+	case FontASCII:
+		m.useNerdFont, m.useUnicodePrefix = false, false
+	default:
+		return m
+	}
+
+	return m.Reflow()
+}
+
+// Reflow returns a new AlertModel whose active alert picks up the model's
+// current width, text width, line limit, ellipsis, border and font, so it
+// re-wraps on the next render. It runs automatically when those options
+// change, so call it yourself only after changes made some other way. The
+// alert keeps its position.
+func (m AlertModel) Reflow() AlertModel {
+	if m.activeAlert == nil {
+		return m
+	}
+
+	// Copied so earlier models keep their own layout
+	active := *m.activeAlert
+	active.width, active.minWidth = m.alertWidths()
+	active.textWidth = m.textWidth
+	active.lineLimit = m.lineLimit
+	active.ellipsis = m.getEllipsis()
+	active.border = m.border
+	if def, ok := m.alertTypes[active.key]; ok {
+		active.prefix = m.prefixFor(def, active.font)
+	}
+	active.pillEnds = m.pillEnds(active.font)
+	m.activeAlert = &active
+	return m
+}
+
+// WithNonTTYFallback returns a new AlertModel that, when stdout is not a
+// terminal (piped or redirected), writes alerts to w as plain "LEVEL: message"
+// lines instead of overlaying them. On a terminal alerts render as usual.
+// This is an immutable operation.
+func (m AlertModel) WithNonTTYFallback(w io.Writer) AlertModel {
+	if term.IsTerminal(int(os.Stdout.Fd())) {
+		m.fallbackWriter = nil
+		return m
+	}
+	m.fallbackWriter = w
+	return m
+}
+
+// WithBorderMatchesType returns a new AlertModel whose alert borders always
+// use each alert type's ForeColor, ignoring any AlertDefinition.BorderColor.
+func (m AlertModel) WithBorderMatchesType() AlertModel {
+	m.borderMatchesType = true
 	return m
 }
 
@@ -82,6 +491,25 @@ func (m AlertModel) WithAllowEscToClose() AlertModel {
 	return m
 }
 
+// WithDismissAllKey returns a new AlertModel where pressing key dismisses
+// every alert at once, including any still waiting to be shown, each
+// reported to the OnDismiss hook with DismissClosed. It works alongside
+// WithAllowEscToClose and doesn't require it. This is an immutable operation.
+func (m AlertModel) WithDismissAllKey(key string) AlertModel {
+	m.dismissAllKey = key
+	return m
+}
+
+// WithCompactBelowWidth returns a new AlertModel that renders alerts in a
+// compact icon-plus-first-word form while the terminal is narrower than
+// cols columns. The terminal width is taken from tea.WindowSizeMsg, so be
+// sure to pass those messages to Update; until the first one arrives, the
+// COLUMNS environment variable is used. This is an immutable operation.
+func (m AlertModel) WithCompactBelowWidth(cols int) AlertModel {
+	m.compactBelowWidth = cols
+	return m
+}
+
 // Init required as part of BubbleTea Model interface
 func (m AlertModel) Init() tea.Cmd {
 	return nil
@@ -91,50 +519,312 @@ func (m AlertModel) Init() tea.Cmd {
 // functionality. First alertMsg starts the ticking command that causes alert
 // refreshing Implemented as part of BubbleTea Model interface
 func (m AlertModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	out, cmd := m.update(msg)
+	next, cmd := out.(AlertModel).promoteTicker(cmd)
+	return next.syncTitle(cmd)
+}
+
+// update does the work of Update, before the terminal title is synced.
+func (m AlertModel) update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	m.escConsumed = false
+	m = m.expireDue()
+	m = m.wakeDue()
+	if m.testMode && !m.paused {
+		m.rotateTicker(m.getClock().Now())
+	}
+
+	if m.activeAlert != nil && m.activeAlert.until != nil && m.activeAlert.until(msg) {
+		// The awaited message arrived; it may still be for us, so carry on
+		m.notifyDismiss(DismissDone)
+		m.activeAlert = nil
+	}
+
 	switch msg := msg.(type) {
 
 	case alertMsg:
-		m.activeAlert = m.newAlert(msg.alertKey, msg.msg, msg.dur)
-		return m, tickCmd() // Start ticking when new alert appears
+		msg.msg = m.cleanMessage(msg.msg)
+		msg.subtitle = m.cleanMessage(msg.subtitle)
+		if msg.dur <= 0 {
+			msg.dur = m.durationFor(msg.alertKey, msg.msg)
+		}
+		if !msg.created {
+			// Held, inboxed and woken alerts are sent again; they were
+			// created when they first arrived
+			m.publish(AlertCreated, msg.spec(), 0)
+			msg.created = true
+		}
+		if m.holdForDoNotDisturb(msg) {
+			return m, nil
+		}
+		if m.fallbackWriter != nil {
+			m.writeFallback(msg)
+			return m, nil
+		}
+		if m.collectInInbox(msg) {
+			return m, nil
+		}
+		if m.collides(msg) && m.idCollision == IDCollisionReject {
+			m.notifySuppressed(msg, DismissRejectedDuplicateID)
+			return m, nil
+		}
+		if m.isRepeat(msg) {
+			// Same alert again: count it on the badge and extend its life
+			m.activeAlert.count++
+			m.activeAlert.message = msg.msg
+			m.restartTimer(m.activeAlert, msg.dur)
+			m.escalate()
+			m.publishActive(AlertUpdated)
+			return m, nil
+		}
+		if !msg.woken && m.suppressRepeat(msg) {
+			m.notifySuppressed(msg, DismissSuppressedAsRepeat)
+			return m, nil
+		}
+		if m.collides(msg) {
+			m.upsert(msg)
+			return m, nil
+		}
+		if m.queueInTicker(msg) {
+			return m, m.soundCmd(msg.alertKey)
+		}
+		if !msg.sequenced {
+			// An unrelated alert takes over, ending any sequence
+			m.sequence = nil
+		}
+		m.notifyDismiss(DismissReplaced)
+		m.activeAlert = m.newAlert(msg)
+		if m.activeAlert == nil && msg.sequenced && len(m.sequence) > 0 {
+			// Skip a step that can't be shown rather than stall the sequence
+			next := m.sequence[0]
+			m.sequence = m.sequence[1:]
+			return m.Update(next)
+		}
+		if m.activeAlert == nil {
+			break
+		}
+		m.rotatedAt = m.activeAlert.birthTime
+		m.publishActive(AlertShown)
+		// Start a new tick chain when new alert appears
+		m.tickID = nextTickID()
+		return m, tea.Batch(m.tickCmd(), m.soundCmd(msg.alertKey))
+
+	case sequenceMsg:
+		if len(msg.steps) == 0 {
+			break
+		}
+		m.sequence = msg.steps[1:]
+		return m.Update(msg.steps[0])
+
+	case cancelSequenceMsg:
+		m.sequence = nil
+
+	case snoozeMsg:
+		return m, m.snooze(msg)
+
+	case dismissKeyMsg:
+		return m, m.dismissByKey(msg.key)
+
+	case wakeMsg:
+		if m.paused {
+			// Resume checks again
+			break
+		}
+		return m.wake()
+
+	case statusMsg:
+		msg.msg = m.cleanMessage(msg.msg)
+		status := alertMsg{id: msg.id, alertKey: msg.alertKey, msg: msg.msg, sticky: true}
+		if m.activeAlert == nil || msg.id == "" || m.activeAlert.id != msg.id || !m.activeAlert.sticky {
+			return m.Update(status)
+		}
+		m.upsert(status)
+
+	case appendMsg:
+		msg.text = m.cleanMessage(msg.text)
+		if m.activeAlert == nil || msg.id == "" || m.activeAlert.id != msg.id {
+			break
+		}
+		m.activeAlert.message += "\n" + msg.text
+		m.activeAlert.following = true
+		if m.refreshOnAppend {
+			m.restartTimer(m.activeAlert, m.activeAlert.dur)
+		}
+		m.publishActive(AlertUpdated)
+
+	case badgeMsg:
+		if m.activeAlert == nil || msg.id == "" || m.activeAlert.id != msg.id {
+			break
+		}
+		m.activeAlert.count = msg.count
+		m.publishActive(AlertUpdated)
+
+	case busyMsg:
+		m.setBusy(msg)
 
 	case tickMsg: // Check to see if it's time to clear the alert
-		if m.activeAlert == nil {
-			// No alert, don't tick
+		if msg.id != m.tickID {
+			// Not our current chain, let it die out
+			break
+		}
+		if m.activeAlert == nil || m.paused {
+			// No alert or paused, don't tick; Resume starts a new chain
 			break
 		}
-		if m.activeAlert.deathTime.Before(time.Time(msg)) {
+		if m.activeAlert.expiredAt(msg.time, m.maxLifetime) {
 			// Alert expired, stop ticking
+			if m.blurred {
+				m.notifyDismiss(DismissExpiredDuringBlur)
+			} else {
+				m.notifyDismiss(DismissExpired)
+			}
+			dismissed := m.activeAlert
 			m.activeAlert = nil
-			break
+			return m, m.advanceSequence(dismissed)
 		}
+		m.rotateTicker(msg.time)
 		// Keep ticking while alert is active
+		if m.activeAlert.flashPhases > 0 {
+			m.activeAlert.flashPhases--
+		}
+		if m.activeAlert.spinner != nil {
+			m.activeAlert.spinFrame++
+		}
 		m.activeAlert.curLerpStep += DefaultLerpIncrement
 		if m.activeAlert.curLerpStep > 1 {
 			m.activeAlert.curLerpStep = 1
 		}
-		return m, tickCmd()
+		return m, m.tickCmd()
+
+	case tea.BlurMsg:
+		m.blurred = true
+		if m.activeAlert != nil && m.activeAlert.focusBoost > 0 {
+			// Back to the original countdown
+			m.activeAlert.deathTime = m.activeAlert.deathTime.Add(-m.activeAlert.focusBoost)
+			m.activeAlert.focusBoost = 0
+		}
+
+	case tea.FocusMsg:
+		m.blurred = false
+		if m.activeAlert != nil && m.extendOnFocus > 0 && m.activeAlert.focusBoost == 0 {
+			m.activeAlert.deathTime = m.activeAlert.deathTime.Add(m.extendOnFocus)
+			m.activeAlert.focusBoost = m.extendOnFocus
+		}
+
+	case tea.WindowSizeMsg:
+		m.termWidth = msg.Width
+		m.termHeight = msg.Height
+
+	case tea.MouseMsg:
+		m.deferForActivity()
+		m.updateHover(msg)
+		return m, m.updateClick(msg)
 
 	case tea.KeyMsg:
+		m.deferForActivity()
+		if m.dndKey != "" && msg.String() == m.dndKey {
+			return m.SetDoNotDisturb(!m.doNotDisturb)
+		}
+		if len(m.inbox) > 0 && msg.String() == m.expandKey {
+			return m, m.expandInbox()
+		}
+		if m.activeAlert != nil && m.activeAlert.confirm != nil {
+			if cmd, ok := m.updateConfirm(msg); ok {
+				return m, cmd
+			}
+		}
+		if m.dismissAllKey != "" && msg.String() == m.dismissAllKey {
+			// Alerts can be waiting with nothing shown, e.g. right after a
+			// snooze, so this doesn't need an active alert
+			if m.activeAlert == nil || !m.armDismiss() {
+				m.closeAll()
+			}
+			break
+		}
 		if m.activeAlert == nil {
 			break
 		}
-		if msg.String() != "esc" {
+		if msg.String() == m.expandKey && m.activeAlert.truncated() {
+			m.activeAlert.expanded = true
 			break
 		}
-		if !m.allowEscToClose {
+		if msg.String() != "esc" || !m.allowEscToClose {
+			// Anything but a dismiss key cancels a pending dismissal
+			m.activeAlert.dismissArmed = false
 			break
 		}
+		if m.armDismiss() {
+			m.escConsumed = true
+			break
+		}
+		m.notifyDismiss(DismissClosed)
+		dismissed := m.activeAlert
 		m.activeAlert = nil
+		m.escConsumed = true
+		return m, m.advanceSequence(dismissed)
 
 	}
 
 	return m, nil
 }
 
+// writeFallback writes the alert as a plain "LEVEL: message" line
+// for output that isn't a terminal.
+func (m AlertModel) writeFallback(msg alertMsg) {
+	if msg.msg == "" {
+		return
+	}
+	if _, ok := m.alertTypes[msg.alertKey]; !ok {
+		return
+	}
+	// Nothing useful can be done if the fallback writer fails
+	_, _ = fmt.Fprintf(m.fallbackWriter, "%s: %s\n", strings.ToUpper(msg.alertKey), msg.msg)
+}
+
+// isRepeat reports whether msg would raise the same alert as the active one,
+// comparing the WithDedupKey key when set, or the type and message otherwise.
+func (m AlertModel) isRepeat(msg alertMsg) bool {
+	n := m.activeAlert
+	if n == nil || n.id != msg.id {
+		return false
+	}
+	key := n.key
+	if n.escalatedFrom != "" {
+		// Keep counting against the type the alert was raised as
+		key = n.escalatedFrom
+	}
+	return m.matchKey(key, n.message) == m.matchKey(msg.alertKey, msg.msg)
+}
+
 // HasActiveAlert allows other models to tell if there is an active already and
 // avoid processing an esc key used to clear an alert
 func (m AlertModel) HasActiveAlert() bool {
-	return m.activeAlert != nil
+	return m.shown()
+}
+
+// HasActiveAlertOfType reports whether an alert of type key is shown or
+// waiting to be shown: held back by do not disturb queueing, snoozed,
+// waiting in the ticker or a sequence, or unread in the inbox.
+func (m AlertModel) HasActiveAlertOfType(key string) bool {
+	if m.heldAlert != nil && m.heldAlert.alertKey == key {
+		return true
+	}
+	if m.hasSnoozed(key) || m.hasInTicker(key) || m.hasInSequence(key) || m.hasInInbox(key) {
+		return true
+	}
+	return m.HasVisibleAlertOfType(key)
+}
+
+// HasVisibleAlertOfType reports whether an alert of type key is currently
+// shown, ignoring any waiting to be shown.
+func (m AlertModel) HasVisibleAlertOfType(key string) bool {
+	return m.shown() && m.activeAlert.key == key
+}
+
+// ConsumeEsc reports whether the most recent Update used an esc key press
+// to dismiss an alert. Check it after passing a tea.KeyMsg to Update to
+// decide whether esc should still propagate, e.g. to quit your app.
+func (m AlertModel) ConsumeEsc() bool {
+	return m.escConsumed
 }
 
 // View doesn't do anything, and it should never be called directly
@@ -147,120 +837,196 @@ func (m AlertModel) View() string {
 // This function expects you build the entirety of your view's content before calling
 // this function. It's recommended for this to be the final call of your model's View().
 // Returns a string representation of the content with overlayed alert.
+// A trailing newline in content is kept, and alerts are positioned on the
+// lines before it, so bottom alerts sit on the last line of actual content.
 func (m AlertModel) Render(content string) string {
-	if m.activeAlert == nil {
+	if m.headless {
 		return content
 	}
+	content, trailing := strings.CutSuffix(content, "\n")
+	out := m.overlay(content)
+	if trailing {
+		out += "\n"
+	}
+	return out
+}
+
+// overlay draws the active alert, inbox badge and top layer onto content.
+func (m AlertModel) overlay(content string) string {
+	if m.sidebarWidth > 0 {
+		return m.renderSidebar(content)
+	}
+	if !m.shown() {
+		return m.drawTopLayer(m.drawInboxBadge(content))
+	}
 
-	notifString := m.activeAlert.render()
+	notifString := m.renderActiveAlert()
 	notifSplit, notifWidth := getLines(notifString)
 	contentSplit, contentWidth := getLines(content)
+	// Kept on the alert so mouse bounds and DebugLayout see the same inset
+	m.activeAlert.inset = m.borderInset(contentSplit)
 	notifHeight := len(notifSplit)
 	contentHeight := len(contentSplit)
 
-	var builder strings.Builder
-	for i := range contentHeight {
-		if i > 0 {
-			builder.WriteByte('\n')
-		}
-
-		line := m.buildLineForPosition(
-			contentSplit[i],
-			notifSplit,
-			i,
-			notifHeight,
-			contentHeight,
-			notifWidth,
-			contentWidth,
-		)
-		builder.WriteString(line)
+	// Only the lines under the alert are rewritten; the rest are reused
+	// as-is and everything is joined back together once.
+	left, startLine := m.alertOrigin(notifWidth, notifHeight, m.referenceWidth(contentWidth), contentHeight)
+	for i := 0; i < notifHeight && startLine+i < contentHeight; i++ {
+		lineIdx := startLine + i
+		contentSplit[lineIdx] = overlayAt(contentSplit[lineIdx], notifSplit[i], left, notifWidth)
 	}
+	m.drawPointer(contentSplit, left, startLine, notifWidth, notifHeight)
 
-	return builder.String()
+	return m.drawTopLayer(m.drawInboxBadge(strings.Join(contentSplit, "\n")))
 }
 
-// buildLineForPosition determines how to overlay notification on content line based on position
-func (m AlertModel) buildLineForPosition(contentLine string, notifLines []string, lineIdx, notifHeight, contentHeight, notifWidth, contentWidth int) string {
-	// Determine if notification should appear on this line
-	var notifIdx int
-	var showNotif bool
-
-	switch m.activeAlert.position {
-	case TopLeftPosition, TopCenterPosition, TopRightPosition:
-		showNotif = lineIdx < notifHeight
-		notifIdx = lineIdx
-	case BottomLeftPosition, BottomCenterPosition, BottomRightPosition:
-		startLine := contentHeight - notifHeight
-		if startLine < 0 {
-			startLine = 0
+// AlertBlocks returns the rendered box of each active alert, without
+// positioning them over any content. Use this instead of Render when you
+// want to place alerts yourself, e.g. with lipgloss.JoinVertical.
+// With WithReservedHeight, a single block of at least that many lines is
+// always returned, blank when no alert is active.
+func (m AlertModel) AlertBlocks() []string {
+	if m.reservedHeight > 0 {
+		block := ""
+		if m.shown() {
+			block = m.renderActiveAlert()
 		}
-		if lineIdx >= startLine {
-			showNotif = true
-			notifIdx = lineIdx - startLine
+		if missing := m.reservedHeight - lipgloss.Height(block); missing > 0 {
+			block += strings.Repeat("\n", missing)
 		}
+		return []string{block}
 	}
-
-	if !showNotif {
-		return contentLine
+	if !m.shown() {
+		return nil
 	}
+	return []string{m.renderActiveAlert()}
+}
 
-	notifLine := notifLines[notifIdx]
+// WithReservedHeight returns a new AlertModel whose AlertBlocks always takes
+// up at least lines lines, blank when no alert is active, so a layout built
+// around it doesn't jump as alerts come and go. Taller alerts still show in
+// full. This is an immutable operation.
+func (m AlertModel) WithReservedHeight(lines int) AlertModel {
+	m.reservedHeight = max(lines, 0)
+	return m
+}
 
-	// Position-specific overlay logic
-	switch m.activeAlert.position {
-	case TopLeftPosition, BottomLeftPosition:
-		return notifLine + cutLeft(contentLine, notifWidth)
+// RenderSnapshot returns how the alerts raised from specs, in order, would
+// look over a blank width by height terminal once fully faded in, without a
+// running program, e.g. to generate screenshots for documentation. The model
+// itself is left untouched and no hooks, sounds or fallback output run.
+func (m AlertModel) RenderSnapshot(specs []AlertSpec, width, height int) string {
+	m.activeAlert = nil
+	m.heldAlert = nil
+	m.doNotDisturb = false
+	m.fallbackWriter = nil
+	m.onDismiss = nil
+	m.eventSink = nil
+	m.lastShown = nil
+	m.termWidth = width
 
-	case TopRightPosition, BottomRightPosition:
-		// Calculate overlay position based on max content width for consistent alignment
-		keepWidth := contentWidth - notifWidth
-		if keepWidth < 0 {
-			// Notification is wider than content - just show notification
-			return notifLine
-		}
-
-		// Check if this specific line is shorter than the overlay position
-		contentLineWidth := ansi.PrintableRuneWidth(contentLine)
-		if contentLineWidth < keepWidth {
-			// Pad the line to reach the overlay position
-			padding := strings.Repeat(" ", keepWidth-contentLineWidth)
-			return contentLine + padding + notifLine
-		}
+	for _, spec := range specs {
+		out, _ := m.Update(m.specMsg(spec))
+		m = out.(AlertModel)
+	}
+	if m.activeAlert != nil {
+		m.activeAlert.curLerpStep = 1
+		m.activeAlert.flashPhases = 0
+	}
 
-		// Line is long enough - cut and overlay
-		return cutRight(contentLine, keepWidth) + notifLine
+	blank := make([]string, height)
+	for i := range blank {
+		blank[i] = strings.Repeat(" ", width)
+	}
+	return m.Render(strings.Join(blank, "\n"))
+}
 
-	case TopCenterPosition, BottomCenterPosition:
-		return m.overlayCenter(contentLine, notifLine, notifWidth, contentWidth)
+// renderActiveAlert renders the active alert, in compact form when the
+// terminal is narrower than the WithCompactBelowWidth threshold.
+func (m AlertModel) renderActiveAlert() string {
+	cols := m.terminalWidth()
+	if m.compactBelowWidth > 0 && cols > 0 && cols < m.compactBelowWidth {
+		return m.activeAlert.renderCompact(cols)
+	}
+	return m.activeAlert.render()
+}
 
-	default:
-		// Fallback to left
-		return notifLine + cutLeft(contentLine, notifWidth)
+// terminalWidth returns the terminal width from the last tea.WindowSizeMsg,
+// falling back to the COLUMNS environment variable before one arrives.
+// It returns 0 when the width is unknown.
+func (m AlertModel) terminalWidth() int {
+	if m.termWidth > 0 {
+		return m.termWidth
+	}
+	cols, err := strconv.Atoi(os.Getenv("COLUMNS"))
+	if err != nil || cols < 0 {
+		return 0
 	}
+	return cols
 }
 
-// overlayCenter overlays notification at center of content line
-// If content is too short, just overlay at position 0
-func (m AlertModel) overlayCenter(contentLine, notifLine string, notifWidth, contentWidth int) string {
-	leftPad := (contentWidth - notifWidth) / 2
-	if leftPad < 0 {
-		leftPad = 0
+// Fingerprint returns a hash of everything that affects how the active alert
+// looks: its identity, message, layout, placement and animation frame. Compare
+// fingerprints across frames to skip recomposing a view when no alert changed.
+// Returns 0 when no alert is active.
+func (m AlertModel) Fingerprint() uint64 {
+	if !m.shown() {
+		return 0
 	}
 
-	contentLen := ansi.PrintableRuneWidth(contentLine)
+	n := m.activeAlert
+	h := fnv.New64a()
+	// Writes to an fnv hash never fail
+	// The render inputs cover the message, layout, colors and frame, so the
+	// fingerprint changes whenever the cached rendering would
+	_, _ = fmt.Fprintf(h, "%s\x00%s\x00%s\x00%d\x00%d\x00%v",
+		n.id, n.key, n.position, m.terminalWidth(), m.terminalHeight(), n.renderInputs())
 
-	// If content line is shorter than where notification should start, just overlay at 0
-	if contentLen < leftPad {
-		return notifLine + cutLeft(contentLine, notifWidth)
+	return h.Sum64()
+}
+
+// startLineForPosition returns the index of the content line the
+// notification's first line overlays, based on position and the vertical
+// offset, kept on screen where the notification fits
+func (m AlertModel) startLineForPosition(pos Position, notifHeight, contentHeight int) int {
+	var startLine int
+	switch pos {
+	case BottomLeftPosition, BottomCenterPosition, BottomRightPosition:
+		startLine = contentHeight - notifHeight - m.verticalOffset
+	default:
+		startLine = m.verticalOffset
 	}
+	return max(min(startLine, contentHeight-notifHeight), 0)
+}
 
-	// Extract left portion (before notification)
-	left := cutRight(contentLine, leftPad)
+// columnForPosition returns the content column the notification's left edge
+// is drawn at, based on position and the horizontal offset, kept on screen
+// where the notification fits
+func (m AlertModel) columnForPosition(pos Position, notifWidth, contentWidth int) int {
+	var col int
+	switch pos {
+	case TopRightPosition, BottomRightPosition:
+		col = contentWidth - notifWidth - m.horizontalOffset
+	case TopCenterPosition, BottomCenterPosition:
+		col = (contentWidth - notifWidth) / 2
+	default:
+		col = m.horizontalOffset
+	}
+	return max(min(col, contentWidth-notifWidth), 0)
+}
+
+// overlayAt overlays notifLine onto contentLine starting at column col.
+// All measurements are in printable cells, so wide characters line up
+// correctly, and short content lines are padded out to the alert.
+func overlayAt(contentLine, notifLine string, col, notifWidth int) string {
+	// Extract left portion (before notification), padded in case the line
+	// is short or a wide character straddles the notification's edge
+	left := padRight(cutRight(contentLine, col), col)
 
 	// Extract right portion (after notification)
-	rightStart := leftPad + notifWidth
+	rightStart := col + notifWidth
 	var right string
-	if rightStart < contentLen {
+	if rightStart < ansi.PrintableRuneWidth(contentLine) {
 		right = cutLeft(contentLine, rightStart)
 	}
 
@@ -270,11 +1036,29 @@ func (m AlertModel) overlayCenter(contentLine, notifLine string, notifWidth, con
 // Timer stuff
 
 // TickMsg is the message that tells the model to assess active alert lifespan.
-type tickMsg time.Time
+// The id ties the tick to the chain of ticks that scheduled it, so models only
+// act on their own latest chain, never on another model's ticks or on a chain
+// left over from a replaced alert.
+type tickMsg struct {
+	id   int64
+	time time.Time
+}
+
+// lastTickID is the id of the most recently started tick chain, across all models.
+var lastTickID atomic.Int64
+
+// nextTickID returns the id for a new tick chain.
+func nextTickID() int64 {
+	return lastTickID.Add(1)
+}
 
 // tickCmd returns a tea Command to initiate a tick.
-func tickCmd() tea.Cmd {
-	return tea.Tick(time.Millisecond*100, func(t time.Time) tea.Msg {
-		return tickMsg(t)
+func (m AlertModel) tickCmd() tea.Cmd {
+	if m.testMode {
+		return nil
+	}
+	id := m.tickID
+	return m.getClock().Tick(DefaultTickInterval, func(t time.Time) tea.Msg {
+		return tickMsg{id: id, time: t}
 	})
 }
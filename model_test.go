@@ -0,0 +1,744 @@
+package bubbleup
+
+import (
+	"bytes"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"golang.org/x/term"
+)
+
+func TestLineLimitTruncatesAndExpands(t *testing.T) {
+	m, _ := newTestModel()
+	m = m.WithLineLimit(3)
+	m = raise(m, InfoKey, "one\ntwo\nthree\nfour\nfive")
+
+	out := m.Render(blank(30, 8))
+	assertContains(t, out, "two")
+	assertNotContains(t, out, "three")
+	assertContains(t, out, "… (+3 lines)")
+
+	m = send(m, keyMsg(DefaultExpandKey))
+	out = m.Render(blank(30, 8))
+	assertContains(t, out, "five")
+	assertNotContains(t, out, "+3 lines")
+}
+
+func TestExpandKeyIgnoredWhenNothingIsCut(t *testing.T) {
+	m, _ := newTestModel()
+	m = m.WithLineLimit(3)
+	m = raise(m, InfoKey, "short")
+
+	if m = send(m, keyMsg(DefaultExpandKey)); m.activeAlert.expanded {
+		t.Error("expected the expand key to pass through an alert that fits")
+	}
+	if !raise(m, InfoKey, "one\ntwo\nthree\nfour").activeAlert.truncated() {
+		t.Error("expected a longer alert to report being cut")
+	}
+}
+
+func TestNonTTYFallbackWritesPlainLines(t *testing.T) {
+	if term.IsTerminal(int(os.Stdout.Fd())) {
+		t.Skip("stdout is a terminal")
+	}
+	var buf bytes.Buffer
+	m, _ := newTestModel()
+	m = m.WithNonTTYFallback(&buf)
+
+	m = raise(m, ErrorKey, "disk full")
+	m = raise(m, InfoKey, "saved")
+
+	if got, want := buf.String(), "ERROR: disk full\nINFO: saved\n"; got != want {
+		t.Errorf("fallback output = %q, want %q", got, want)
+	}
+	if m.HasActiveAlert() {
+		t.Error("fallback alerts shouldn't be shown")
+	}
+}
+
+func TestTextWidthWrapsInsideWiderBox(t *testing.T) {
+	m, _ := newTestModel()
+	m = m.WithTextWidth(12)
+	m = raise(m, InfoKey, "aaa bbb ccc ddd")
+
+	lines := strings.Split(m.activeAlert.render(), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("got %d lines, want the message wrapped onto 2:\n%s", len(lines), strings.Join(lines, "\n"))
+	}
+	for _, line := range lines {
+		if w := lipgloss.Width(line); w != 22 {
+			t.Errorf("line %q is %d wide, want the box's 22", line, w)
+		}
+	}
+	assertContains(t, lines[1], "(i) aaa bbb  ")
+	assertContains(t, lines[2], "    ccc ddd  ")
+}
+
+func TestTextWidthClampedToBox(t *testing.T) {
+	m, _ := newTestModel()
+	m = m.WithTextWidth(50)
+	m = raise(m, InfoKey, "aaa bbb ccc ddd eee fff")
+
+	for _, line := range strings.Split(m.activeAlert.render(), "\n") {
+		if w := lipgloss.Width(line); w != 22 {
+			t.Errorf("line %q is %d wide, want the box's 22", line, w)
+		}
+	}
+}
+
+func TestAlertBlocksMatchOverlay(t *testing.T) {
+	m, _ := newTestModel()
+	if blocks := m.AlertBlocks(); blocks != nil {
+		t.Errorf("AlertBlocks() with no alert = %q, want nil", blocks)
+	}
+
+	m = raise(m, WarnKey, "careful now")
+	blocks := m.AlertBlocks()
+	if len(blocks) != 1 {
+		t.Fatalf("got %d blocks, want 1", len(blocks))
+	}
+	rendered := strings.Split(m.Render(blank(40, 6)), "\n")
+	for i, line := range strings.Split(blocks[0], "\n") {
+		if !strings.HasPrefix(rendered[i], line) {
+			t.Errorf("overlay line %d = %q, want it to start with the block's %q", i, rendered[i], line)
+		}
+	}
+}
+
+func TestDismissAllKeyClearsEverything(t *testing.T) {
+	m, _ := newTestModel()
+	m = m.WithDismissAllKey("ctrl+x")
+	m = send(m, m.NewAlertSequenceCmd([]AlertSpec{
+		{Key: InfoKey, Message: "step 1"},
+		{Key: InfoKey, Message: "step 2"},
+	})())
+	if !m.HasActiveAlert() {
+		t.Fatal("expected the first step to show")
+	}
+
+	m = send(m, keyMsg("x"))
+	if !m.HasActiveAlert() {
+		t.Fatal("other keys shouldn't dismiss")
+	}
+	m = send(m, keyMsg("ctrl+x"))
+	if m.HasActiveAlert() {
+		t.Error("expected no active alert after the dismiss-all key")
+	}
+	if len(m.sequence) != 0 {
+		t.Errorf("expected the sequence to be cleared, %d steps left", len(m.sequence))
+	}
+	if m.ConsumeEsc() {
+		t.Error("the dismiss-all key isn't esc, so esc shouldn't be consumed")
+	}
+}
+
+func TestCompactBelowWidth(t *testing.T) {
+	m, _ := newTestModel()
+	m = m.WithCompactBelowWidth(40)
+	m = raise(m, InfoKey, "hello there world")
+
+	m = send(m, tea.WindowSizeMsg{Width: 30, Height: 10})
+	block := m.AlertBlocks()[0]
+	assertContains(t, block, "(i) hello…")
+	assertNotContains(t, block, "there")
+	if h := lipgloss.Height(block); h != 3 {
+		t.Errorf("compact alert is %d lines, want 3", h)
+	}
+
+	m = send(m, tea.WindowSizeMsg{Width: 80, Height: 10})
+	assertContains(t, m.AlertBlocks()[0], "hello there")
+}
+
+func TestFingerprintChangesOnlyWithTheAlert(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	m := NewAlertModel(20, false, 10).WithClock(clock)
+	if m.Fingerprint() != 0 {
+		t.Error("expected a zero fingerprint with no alert")
+	}
+
+	out, cmd := m.Update(m.NewAlertCmd(InfoKey, "hi")())
+	m = out.(AlertModel)
+	added := m.Fingerprint()
+	if added == 0 {
+		t.Fatal("expected a fingerprint once an alert shows")
+	}
+
+	// Tick until the fade-in settles
+	var prints []uint64
+	for range 10 {
+		clock.advance(DefaultTickInterval)
+		out, cmd = m.Update(runCmd(cmd)[0])
+		m = out.(AlertModel)
+		prints = append(prints, m.Fingerprint())
+	}
+	if prints[0] == added {
+		t.Error("expected the fingerprint to follow the fade-in")
+	}
+	if prints[len(prints)-2] != prints[len(prints)-1] {
+		t.Error("expected the fingerprint to be stable across no-op ticks")
+	}
+
+	if m = raise(m, InfoKey, "bye"); m.Fingerprint() == prints[len(prints)-1] {
+		t.Error("expected the fingerprint to change with a new alert")
+	}
+}
+
+func TestCustomEllipsis(t *testing.T) {
+	if got := stripANSI(truncate("abcdefghij", 6, "...")); got != "abc..." {
+		t.Errorf("truncate with a 3 column ellipsis = %q, want %q", got, "abc...")
+	}
+
+	m, _ := newTestModel()
+	m = m.WithEllipsis("...").WithLineLimit(2)
+	m = raise(m, InfoKey, "one\ntwo\nthree")
+	assertContains(t, m.activeAlert.render(), "    ... (+2 lines)")
+
+	// The compact box still fits the terminal with the wider ellipsis
+	m = m.WithCompactBelowWidth(40)
+	m = send(m, tea.WindowSizeMsg{Width: 12, Height: 5})
+	block := m.AlertBlocks()[0]
+	assertContains(t, block, "...")
+	if w := lipgloss.Width(block); w > 12 {
+		t.Errorf("compact alert is %d wide, want at most 12:\n%s", w, block)
+	}
+}
+
+func TestConsumeEscOnlyWhenDismissing(t *testing.T) {
+	m, _ := newTestModel()
+	m = m.WithAllowEscToClose()
+
+	m = send(m, keyMsg("esc"))
+	if m.ConsumeEsc() {
+		t.Error("esc with no alert shouldn't be consumed")
+	}
+
+	m = raise(m, InfoKey, "hi")
+	m = send(m, keyMsg("x"))
+	if m.ConsumeEsc() {
+		t.Error("other keys shouldn't be consumed")
+	}
+	m = send(m, keyMsg("esc"))
+	if !m.ConsumeEsc() || m.HasActiveAlert() {
+		t.Error("esc dismissing the alert should be consumed")
+	}
+	m = send(m, keyMsg("esc"))
+	if m.ConsumeEsc() {
+		t.Error("a second esc, with nothing left to dismiss, shouldn't be consumed")
+	}
+
+	// Without WithAllowEscToClose, esc is left for the app
+	m, _ = newTestModel()
+	m = send(raise(m, InfoKey, "hi"), keyMsg("esc"))
+	if m.ConsumeEsc() || !m.HasActiveAlert() {
+		t.Error("esc shouldn't dismiss without WithAllowEscToClose")
+	}
+}
+
+func TestMaxLifetimeCapsActivity(t *testing.T) {
+	m, clock := newTestModel()
+	m = m.WithMaxLifetime(20 * time.Second)
+
+	// Each repeat restarts the 10 second countdown
+	m = raise(m, InfoKey, "busy")
+	for elapsed := 5; elapsed <= 15; elapsed += 5 {
+		clock.advance(5 * time.Second)
+		m = raise(m, InfoKey, "busy")
+		if !m.HasActiveAlert() {
+			t.Fatalf("alert gone after %ds, before its max lifetime", elapsed)
+		}
+	}
+
+	clock.advance(5 * time.Second)
+	if m = send(m, struct{}{}); m.HasActiveAlert() {
+		t.Error("expected the alert to be dismissed at its max lifetime")
+	}
+
+	// Pausing doesn't stretch the ceiling
+	m = send(m.WithMaxLifetime(5*time.Second), m.NewAlertCmd(InfoKey, "paused")())
+	clock.advance(2 * time.Second)
+	m = m.Pause()
+	clock.advance(time.Minute)
+	if !m.HasActiveAlert() {
+		t.Fatal("expected the alert to stay while paused")
+	}
+	m, _ = m.Resume()
+	if m = send(m, struct{}{}); m.HasActiveAlert() {
+		t.Error("expected the alert past its max lifetime to go once resumed")
+	}
+}
+
+func TestHasAlertOfType(t *testing.T) {
+	m, _ := newTestModel()
+	m = raise(m, ErrorKey, "boom")
+
+	// Hold a warning back for later
+	m = m.WithDoNotDisturbMode(DoNotDisturbQueue)
+	m, _ = m.SetDoNotDisturb(true)
+	m = raise(m, WarnKey, "careful")
+
+	tests := []struct {
+		key             string
+		active, visible bool
+	}{
+		{ErrorKey, true, true},
+		{WarnKey, true, false},
+		{InfoKey, false, false},
+	}
+	for _, tt := range tests {
+		if got := m.HasActiveAlertOfType(tt.key); got != tt.active {
+			t.Errorf("HasActiveAlertOfType(%s) = %v, want %v", tt.key, got, tt.active)
+		}
+		if got := m.HasVisibleAlertOfType(tt.key); got != tt.visible {
+			t.Errorf("HasVisibleAlertOfType(%s) = %v, want %v", tt.key, got, tt.visible)
+		}
+	}
+}
+
+func TestHasActiveAlertOfTypeCountsSequenceAndInbox(t *testing.T) {
+	m, _ := newTestModel()
+	m = send(m, m.NewAlertSequenceCmd([]AlertSpec{
+		{Key: InfoKey, Message: "step 1"},
+		{Key: WarnKey, Message: "step 2"},
+	})())
+	if !m.HasActiveAlertOfType(WarnKey) || m.HasVisibleAlertOfType(WarnKey) {
+		t.Error("expected the waiting sequence step to count as active only")
+	}
+
+	inbox, _ := newTestModel()
+	inbox = raise(inbox.WithInboxBadge(TopRightPosition), ErrorKey, "unread")
+	if !inbox.HasActiveAlertOfType(ErrorKey) || inbox.HasVisibleAlertOfType(ErrorKey) {
+		t.Error("expected the unread inbox alert to count as active only")
+	}
+	if inbox.AcknowledgeInbox().HasActiveAlertOfType(ErrorKey) {
+		t.Error("expected an acknowledged alert to no longer count")
+	}
+}
+
+// appTick is a tick message an app might define for itself.
+type appTick time.Time
+
+func TestAppTicksPassThrough(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	m := NewAlertModel(20, false, 10).WithClock(clock)
+	out, _ := m.Update(m.NewAlertCmd(InfoKey, "hi")())
+	m = out.(AlertModel)
+	before := m.Fingerprint()
+
+	for _, msg := range []tea.Msg{appTick(clock.now), clock.now} {
+		out, cmd := m.Update(msg)
+		if cmd != nil {
+			t.Errorf("Update(%T) returned a command for an app's tick", msg)
+		}
+		if out.(AlertModel).Fingerprint() != before {
+			t.Errorf("Update(%T) changed the alert", msg)
+		}
+	}
+}
+
+func TestStaleTicksAreIgnored(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	m := NewAlertModel(20, false, 10).WithClock(clock)
+	out, first := m.Update(m.NewAlertCmd(InfoKey, "old")())
+	m = out.(AlertModel)
+	out, second := m.Update(m.NewAlertCmd(WarnKey, "new")())
+	m = out.(AlertModel)
+	step := m.activeAlert.curLerpStep
+
+	// The replaced alert's chain dies out without touching the new alert
+	out, cmd := m.Update(runCmd(first)[0])
+	m = out.(AlertModel)
+	if cmd != nil {
+		t.Error("expected a stale tick to end its chain")
+	}
+	if m.activeAlert.curLerpStep != step {
+		t.Error("expected a stale tick not to animate the new alert")
+	}
+
+	// The new chain carries on
+	var msg tea.Msg
+	for _, msg = range runCmd(second) {
+		if _, ok := msg.(tickMsg); ok {
+			break
+		}
+	}
+	out, cmd = m.Update(msg)
+	if cmd == nil || out.(AlertModel).activeAlert.curLerpStep == step {
+		t.Error("expected the current chain to keep ticking")
+	}
+}
+
+func TestDedupKeyCoalescesTemplatedMessages(t *testing.T) {
+	stripNumbers := func(key, message string) string {
+		return key + strings.TrimRight(message, "0123456789")
+	}
+
+	m, _ := newTestModel()
+	m = raise(m, ErrorKey, "request failed: 1041")
+	m = raise(m, ErrorKey, "request failed: 1042")
+	if m.activeAlert.count != 1 {
+		t.Error("without a dedup key, different messages shouldn't coalesce")
+	}
+
+	m, _ = newTestModel()
+	m = m.WithDedupKey(stripNumbers)
+	m = raise(m, ErrorKey, "request failed: 1041")
+	m = raise(m, ErrorKey, "request failed: 1042")
+	if m.activeAlert.count != 2 {
+		t.Errorf("count = %d, want the two messages coalesced", m.activeAlert.count)
+	}
+	if m.activeAlert.message != "request failed: 1042" {
+		t.Errorf("message = %q, want the latest", m.activeAlert.message)
+	}
+
+	m = raise(m, WarnKey, "request failed: 1043")
+	if m.activeAlert.count != 1 {
+		t.Error("a different derived key shouldn't coalesce")
+	}
+}
+
+func TestFlashCyclesThenSettles(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	m := NewAlertModel(20, false, 10).WithClock(clock).WithFlash(3, ErrorKey)
+	out, cmd := m.Update(m.NewAlertCmd(ErrorKey, "boom")())
+	m = out.(AlertModel)
+
+	var frames []bool
+	for range 10 {
+		out, cmd = m.Update(runCmd(cmd)[0])
+		m = out.(AlertModel)
+		frames = append(frames, m.activeAlert.flashing())
+	}
+	want := []bool{true, false, true, false, true, false, false, false, false, false}
+	if !slices.Equal(frames, want) {
+		t.Errorf("flash frames = %v, want %v", frames, want)
+	}
+
+	// Other types don't flash
+	m = raise(m, InfoKey, "calm")
+	if m.activeAlert.flashPhases != 0 {
+		t.Error("expected only Error alerts to flash")
+	}
+
+	// Test mode skips the flash along with the other animation
+	m, _ = newTestModel()
+	if m = raise(m.WithFlash(3), ErrorKey, "boom"); m.activeAlert.flashPhases != 0 {
+		t.Error("expected no flash in test mode")
+	}
+}
+
+// naiveOverlay is how Render used to overlay the alert: every content
+// line is copied into a builder, whether the alert covers it or not.
+func naiveOverlay(m AlertModel, content string) string {
+	notifSplit, notifWidth := getLines(m.renderActiveAlert())
+	contentSplit, contentWidth := getLines(content)
+	startLine := m.startLineForPosition(m.activeAlert.position, len(notifSplit), len(contentSplit))
+	left := m.columnForPosition(m.activeAlert.position, notifWidth, contentWidth)
+
+	var builder strings.Builder
+	for i, line := range contentSplit {
+		if i > 0 {
+			builder.WriteByte('\n')
+		}
+		if i >= startLine && i < startLine+len(notifSplit) {
+			line = overlayAt(line, notifSplit[i-startLine], left, notifWidth)
+		}
+		builder.WriteString(line)
+	}
+	return builder.String()
+}
+
+// benchmarkModel returns a model showing a small alert, with a large buffer
+// to render it over.
+func benchmarkModel() (AlertModel, string) {
+	m, _ := newTestModel()
+	m = raise(m, InfoKey, "saved")
+	return m, blank(200, 500)
+}
+
+func TestRenderMatchesNaiveOverlay(t *testing.T) {
+	m, content := benchmarkModel()
+	for _, pos := range []Position{TopLeftPosition, TopCenterPosition, BottomRightPosition} {
+		m.activeAlert.position = pos
+		if got, want := m.Render(content), naiveOverlay(m, content); got != want {
+			t.Errorf("%s: Render differs from the naive overlay", pos)
+		}
+	}
+}
+
+func BenchmarkRender(b *testing.B) {
+	m, content := benchmarkModel()
+	b.ReportAllocs()
+	for b.Loop() {
+		m.Render(content)
+	}
+}
+
+func BenchmarkRenderNaive(b *testing.B) {
+	m, content := benchmarkModel()
+	b.ReportAllocs()
+	for b.Loop() {
+		naiveOverlay(m, content)
+	}
+}
+
+func TestExtendOnFocusAddsTimeUntilBlur(t *testing.T) {
+	m, clock := newTestModel()
+	m = raise(m.WithExtendOnFocus(5*time.Second), InfoKey, "read me")
+	original := m.activeAlert.deathTime
+
+	m = send(m, tea.FocusMsg{}, tea.FocusMsg{})
+	if got := m.activeAlert.deathTime.Sub(original); got != 5*time.Second {
+		t.Fatalf("expected focus to add 5s once, added %v", got)
+	}
+
+	// The boost keeps the alert up past its original 10 seconds
+	clock.advance(12 * time.Second)
+	if m = send(m, nil); m.activeAlert == nil {
+		t.Fatal("expected the focused alert to outlive its original duration")
+	}
+
+	m = send(m, tea.BlurMsg{})
+	if m.activeAlert != nil && !m.activeAlert.deathTime.Equal(original) {
+		t.Errorf("expected blur to restore the original countdown, got %v", m.activeAlert.deathTime.Sub(original))
+	}
+	if m = send(m, nil); m.activeAlert != nil {
+		t.Error("expected the alert to expire on its original schedule after blur")
+	}
+}
+
+func TestSizePresetsSetWidths(t *testing.T) {
+	for _, tt := range []struct {
+		preset        SizePreset
+		minWidth, max int
+	}{
+		{SizeSmall, 20, 40},
+		{SizeMedium, 30, 60},
+		{SizeLarge, 40, 80},
+	} {
+		m := NewAlertModel(10, false, 10).WithSizePreset(tt.preset)
+		if m.minWidth != tt.minWidth || m.width != tt.max {
+			t.Errorf("preset %d: widths %d to %d, want %d to %d", tt.preset, m.minWidth, m.width, tt.minWidth, tt.max)
+		}
+	}
+
+	m := NewAlertModel(25, false, 10).WithSizePreset(SizePreset(99))
+	if m.width != 25 {
+		t.Errorf("expected an unknown preset to keep width 25, got %d", m.width)
+	}
+}
+
+func TestTerminalSizeFallsBackToEnv(t *testing.T) {
+	t.Setenv("COLUMNS", "30")
+	t.Setenv("LINES", "12")
+	m, _ := newTestModel()
+
+	if w, h := m.terminalWidth(), m.terminalHeight(); w != 30 || h != 12 {
+		t.Errorf("before any WindowSizeMsg, size = %dx%d, want 30x12", w, h)
+	}
+	// The env width is enough to pick the compact form on the first render
+	compact := raise(m.WithCompactBelowWidth(40), InfoKey, "hi")
+	if got := compact.renderActiveAlert(); got != compact.activeAlert.renderCompact(30) {
+		t.Errorf("expected the compact form at 30 columns, got:\n%s", got)
+	}
+
+	m = send(m, tea.WindowSizeMsg{Width: 50, Height: 20})
+	if w, h := m.terminalWidth(), m.terminalHeight(); w != 50 || h != 20 {
+		t.Errorf("after a WindowSizeMsg, size = %dx%d, want 50x20", w, h)
+	}
+}
+
+func TestTerminalSizeIgnoresInvalidEnv(t *testing.T) {
+	t.Setenv("COLUMNS", "wide")
+	t.Setenv("LINES", "-3")
+	m, _ := newTestModel()
+
+	if w, h := m.terminalWidth(), m.terminalHeight(); w != 0 || h != 0 {
+		t.Errorf("size = %dx%d, want unknown", w, h)
+	}
+}
+
+func TestMessagesAreSanitizedUnlessRaw(t *testing.T) {
+	m, _ := newTestModel()
+	const msg = "50%\rdone\x1b[2A"
+
+	if got := raise(m, InfoKey, msg).activeAlert.message; got != "50%done" {
+		t.Errorf("message = %q, want the carriage return and cursor move stripped", got)
+	}
+	if got := raise(m.WithRawMessages(), InfoKey, msg).activeAlert.message; got != msg {
+		t.Errorf("raw message = %q, want %q", got, msg)
+	}
+}
+
+func TestRenderSnapshotMatchesRender(t *testing.T) {
+	withTrueColor(t)
+	specs := []AlertSpec{
+		{Key: InfoKey, Message: "replaced"},
+		{Key: WarnKey, Message: "disk almost full", Subtitle: "3% left"},
+	}
+	m, _ := newTestModel()
+	m = m.WithPosition(BottomRightPosition)
+
+	live := m
+	for _, spec := range specs {
+		live = send(live, live.NewAlertCmdFromSpec(spec)())
+	}
+	live = send(live, tea.WindowSizeMsg{Width: 40, Height: 8})
+
+	if got, want := m.RenderSnapshot(specs, 40, 8), live.Render(blank(40, 8)); got != want {
+		t.Errorf("snapshot:\n%s\nwant:\n%s", got, want)
+	}
+	if m.activeAlert != nil {
+		t.Error("expected RenderSnapshot to leave the model untouched")
+	}
+}
+
+func TestPaddedMessageRendersLikeTrimmed(t *testing.T) {
+	m, _ := newTestModel()
+	m = m.WithPosition(TopCenterPosition)
+
+	padded := raise(m, InfoKey, "  saved  \n\tall files ").Render(blank(40, 6))
+	trimmed := raise(m, InfoKey, "saved\nall files").Render(blank(40, 6))
+	if padded != trimmed {
+		t.Errorf("padded message:\n%s\nwant:\n%s", padded, trimmed)
+	}
+
+	kept := raise(m.WithTrimMessages(false), InfoKey, "  indented")
+	if got := kept.activeAlert.message; got != "  indented" {
+		t.Errorf("message = %q, want the indentation kept", got)
+	}
+}
+
+func TestSetFontModeUpdatesActivePrefix(t *testing.T) {
+	m, _ := newTestModel()
+	m = raise(m, InfoKey, "hi")
+
+	for _, tt := range []struct{ mode, prefix string }{
+		{FontUnicode, InfoUnicodePrefix},
+		{FontNerd, InfoNerdSymbol},
+		{FontASCII, InfoASCIIPrefix},
+	} {
+		m = m.SetFontMode(tt.mode)
+		if got := m.FontMode(); got != tt.mode {
+			t.Errorf("FontMode() = %q, want %q", got, tt.mode)
+		}
+		assertContains(t, stripANSI(m.activeAlert.render()), strings.TrimSpace(tt.prefix)+" ")
+	}
+
+	if m = m.SetFontMode("comic sans"); m.FontMode() != FontASCII {
+		t.Errorf("expected an unknown mode to be ignored, got %q", m.FontMode())
+	}
+}
+
+func TestMaxMessageLengthTruncatesWithEllipsis(t *testing.T) {
+	m, _ := newTestModel()
+	m = m.WithMaxMessageLength(8).WithEllipsis("[cut]")
+
+	if got := raise(m, InfoKey, "abcdefghijklmnop").activeAlert.message; got != "abcdefgh[cut]" {
+		t.Errorf("message = %q, want it cut to 8 runes with the ellipsis", got)
+	}
+	if got := raise(m, InfoKey, "日本語のテキストです").activeAlert.message; got != "日本語のテキスト[cut]" {
+		t.Errorf("message = %q, want 8 runes rather than 8 bytes", got)
+	}
+	if got := raise(m, InfoKey, "abcdefgh").activeAlert.message; got != "abcdefgh" {
+		t.Errorf("message = %q, want a message of exactly 8 runes kept", got)
+	}
+}
+
+func TestReflowRewrapsToNewWidth(t *testing.T) {
+	m, _ := newTestModel()
+	m = raise(m, InfoKey, "a message that wraps over lines")
+	narrow := m.Fingerprint()
+	if h := lipgloss.Height(m.activeAlert.render()); h != 5 {
+		t.Fatalf("height at width 20 = %d, want 5", h)
+	}
+
+	wide := m.WithWidth(40)
+	lines := strings.Split(stripANSI(wide.activeAlert.render()), "\n")
+	if len(lines) != 3 || lipgloss.Width(lines[0]) != 42 {
+		t.Errorf("expected one line in a 42 column box, got:\n%s", strings.Join(lines, "\n"))
+	}
+	if wide.Fingerprint() == narrow {
+		t.Error("expected the fingerprint to change with the width")
+	}
+	if h := lipgloss.Height(m.activeAlert.render()); h != 5 {
+		t.Errorf("expected the earlier model to keep its layout, height %d", h)
+	}
+
+	// Options changed on the model only reach the alert through Reflow
+	m.lineLimit = 1
+	if got := m.Reflow(); got.activeAlert.lineLimit != 1 || got.Fingerprint() == narrow {
+		t.Error("expected Reflow to pick up the line limit")
+	}
+}
+
+func TestTrailingNewlineKeepsOverlayPositions(t *testing.T) {
+	for _, pos := range []Position{TopLeftPosition, BottomRightPosition} {
+		m, _ := newTestModel()
+		m = raise(m.WithPosition(pos), InfoKey, "hi")
+
+		without := m.Render(blank(30, 6))
+		with := m.Render(blank(30, 6) + "\n")
+		if with != without+"\n" {
+			t.Errorf("%s: with a trailing newline got:\n%q\nwant:\n%q", pos, with, without+"\n")
+		}
+	}
+}
+
+func TestDurationPerCharScalesWithinCap(t *testing.T) {
+	m, _ := newTestModel()
+	m = m.WithDurationPerChar(2*time.Second, 100*time.Millisecond).WithDurationCap(5 * time.Second)
+
+	for _, tt := range []struct {
+		message string
+		want    time.Duration
+	}{
+		{"ok", 2200 * time.Millisecond},
+		{"saved 10 files", 3400 * time.Millisecond},
+		{strings.Repeat("long ", 20), 5 * time.Second},
+	} {
+		if got := raise(m, InfoKey, tt.message).activeAlert.dur; got != tt.want {
+			t.Errorf("duration for %d runes = %v, want %v", len(tt.message), got, tt.want)
+		}
+	}
+
+	// A spec's own duration still wins
+	spec := send(m, m.NewAlertCmdFromSpec(AlertSpec{Key: InfoKey, Message: "ok", Duration: 30})())
+	if got := spec.activeAlert.dur; got != 30*time.Second {
+		t.Errorf("spec duration = %v, want 30s", got)
+	}
+}
+
+func TestReservedHeightKeepsAlertBlocksSteady(t *testing.T) {
+	m, clock := newTestModel()
+	m = m.WithReservedHeight(5)
+
+	heights := func(blocks []string) []int {
+		var hs []int
+		for _, b := range blocks {
+			hs = append(hs, lipgloss.Height(b))
+		}
+		return hs
+	}
+	if got := heights(m.AlertBlocks()); !slices.Equal(got, []int{5}) {
+		t.Errorf("heights with no alert = %v, want [5]", got)
+	}
+	m = raise(m, InfoKey, "saved")
+	if got := heights(m.AlertBlocks()); !slices.Equal(got, []int{5}) {
+		t.Errorf("heights with an alert = %v, want [5]", got)
+	}
+	clock.advance(11 * time.Second)
+	if m = send(m, struct{}{}); !slices.Equal(heights(m.AlertBlocks()), []int{5}) {
+		t.Errorf("heights once expired = %v, want [5]", heights(m.AlertBlocks()))
+	}
+
+	// Taller alerts aren't cut
+	m = raise(m, InfoKey, strings.Repeat("a long message ", 8))
+	if got := heights(m.AlertBlocks()); len(got) != 1 || got[0] <= 5 {
+		t.Errorf("heights with a tall alert = %v, want one block over 5 lines", got)
+	}
+}
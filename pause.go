@@ -0,0 +1,88 @@
+package bubbleup
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Pause returns a new AlertModel with every alert timer and animation frozen,
+// e.g. while the app shows a modal of its own. Unlike do not disturb, alerts
+// keep showing and new ones still appear, but nothing counts down or fades
+// until Resume. Snoozed alerts stay snoozed.
+func (m AlertModel) Pause() AlertModel {
+	if m.paused {
+		return m
+	}
+	m.paused = true
+	m.pausedAt = m.getClock().Now()
+	return m
+}
+
+// Resume returns a new AlertModel that carries on from where Pause left
+// off, with the time each alert had left preserved, along with the tea.Cmd
+// that restarts its timers; be sure to return it from Update. WithMaxLifetime
+// still counts from when each alert appeared, pauses included.
+func (m AlertModel) Resume() (AlertModel, tea.Cmd) {
+	if !m.paused {
+		return m, nil
+	}
+	m.paused = false
+	now := m.getClock().Now()
+
+	var cmds []tea.Cmd
+	if m.activeAlert != nil {
+		// Copied so earlier models keep their own timers. The birth time
+		// stays put, as WithMaxLifetime is a ceiling regardless of pauses.
+		active := *m.activeAlert
+		shift := now.Sub(laterOf(m.pausedAt, active.birthTime))
+		active.deathTime = active.deathTime.Add(shift)
+		if !active.hoveredAt.IsZero() {
+			active.hoveredAt = active.hoveredAt.Add(shift)
+		}
+		m.activeAlert = &active
+		m.tickID = nextTickID()
+		cmds = append(cmds, m.tickCmd())
+	}
+
+	if len(m.ticker) > 0 {
+		ticker := make([]*alert, len(m.ticker))
+		for i, n := range m.ticker {
+			waiting := *n
+			shift := now.Sub(laterOf(m.pausedAt, waiting.birthTime))
+			waiting.deathTime = waiting.deathTime.Add(shift)
+			ticker[i] = &waiting
+		}
+		m.ticker = ticker
+		m.rotatedAt = m.rotatedAt.Add(now.Sub(m.pausedAt))
+	}
+
+	if len(m.snoozed) > 0 {
+		snoozed := make([]snoozedAlert, len(m.snoozed))
+		next := time.Time{}
+		for i, s := range m.snoozed {
+			s.wakeAt = s.wakeAt.Add(now.Sub(m.pausedAt))
+			if next.IsZero() || s.wakeAt.Before(next) {
+				next = s.wakeAt
+			}
+			snoozed[i] = s
+		}
+		m.snoozed = snoozed
+		cmds = append(cmds, m.wakeCmd(next.Sub(now)))
+	}
+
+	return m, tea.Batch(cmds...)
+}
+
+// IsPaused reports whether alert timers are frozen by Pause.
+func (m AlertModel) IsPaused() bool {
+	return m.paused
+}
+
+// laterOf returns the later of a and b.
+func laterOf(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,52 @@
+package bubbleup
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPausePreservesRemainingTime(t *testing.T) {
+	m, clock := newTestModel()
+	m = raise(m, InfoKey, "paused")
+	clock.advance(4 * time.Second)
+
+	m = m.Pause()
+	clock.advance(time.Minute)
+	if m = send(m, struct{}{}); m.activeAlert == nil || !m.IsPaused() {
+		t.Fatal("expected the alert to stay up while paused")
+	}
+
+	m, _ = m.Resume()
+	clock.advance(5 * time.Second)
+	if m = send(m, struct{}{}); m.activeAlert == nil {
+		t.Fatal("expected the alert to carry on with the 6 seconds it had left")
+	}
+	clock.advance(2 * time.Second)
+	if m = send(m, struct{}{}); m.activeAlert != nil {
+		t.Error("expected the alert to expire once its remaining time was up")
+	}
+}
+
+func TestPauseFreezesAnimation(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	m := NewAlertModel(20, false, 10).WithClock(clock)
+	out, cmd := m.Update(m.NewAlertCmd(InfoKey, "fading in")())
+	m = out.(AlertModel).Pause()
+
+	step := m.activeAlert.curLerpStep
+	clock.advance(DefaultTickInterval)
+	if out, cmd = m.Update(runCmd(cmd)[0]); cmd != nil {
+		t.Error("expected ticks to stop while paused")
+	}
+	if m = out.(AlertModel); m.activeAlert.curLerpStep != step {
+		t.Errorf("fade moved from %v to %v while paused", step, m.activeAlert.curLerpStep)
+	}
+
+	if m, cmd = m.Resume(); cmd == nil {
+		t.Fatal("expected Resume to restart the ticks")
+	}
+	clock.advance(DefaultTickInterval)
+	if out, _ = m.Update(runCmd(cmd)[0]); out.(AlertModel).activeAlert.curLerpStep <= step {
+		t.Error("expected the fade to carry on after Resume")
+	}
+}
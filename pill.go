@@ -0,0 +1,58 @@
+package bubbleup
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Rounded powerline ends of a pill drawn with a nerd font
+const (
+	pillNerdLeft  = "\ue0b6"
+	pillNerdRight = "\ue0b4"
+)
+
+// pillEnds returns the left and right ends of a KindPill alert for font, or
+// the model's font mode when font is "". Nerd fonts get filled rounded ends,
+// other fonts parentheses, and ASCII brackets.
+func (m AlertModel) pillEnds(font string) [2]string {
+	if font == "" {
+		font = m.FontMode()
+	}
+	switch font {
+	case FontNerd:
+		return [2]string{pillNerdLeft, pillNerdRight}
+	case FontUnicode:
+		return [2]string{"(", ")"}
+	default:
+		return [2]string{"[", "]"}
+	}
+}
+
+// renderPill renders the alert as a single-line badge of its icon, any
+// repeat badge and its message, cut to fit within maxWidth columns. With
+// nerd font ends the badge is filled with the border color.
+func (n *alert) renderPill(maxWidth int, iconColor, textColor, borderColor lipgloss.Color) string {
+	badge := n.badge()
+	prefix := n.icon()
+	if badge != "" {
+		prefix += " " + badge
+	}
+	message := strings.Join(strings.Fields(n.message), " ")
+
+	// Leave room for the ends and the padding inside them
+	content := truncate(prefix+" "+message, maxWidth-4, n.ellipsis)
+
+	endStyle := lipgloss.NewStyle().Foreground(borderColor)
+	left, right := endStyle.Render(n.pillEnds[0]), endStyle.Render(n.pillEnds[1])
+	if n.pillEnds[0] == pillNerdLeft {
+		fill := lipgloss.NewStyle().Background(borderColor).Foreground(lipgloss.Color(backColor.Hex()))
+		return left + fill.Render(" "+content+" ") + right
+	}
+	if badge != "" || n.iconColor != n.textColor {
+		content = styleHead(content, n.icon()+" ", badge, iconColor, textColor)
+	} else {
+		content = lipgloss.NewStyle().Foreground(textColor).Render(content)
+	}
+	return left + " " + content + " " + right
+}
@@ -52,3 +52,23 @@ const (
 	BottomRightPosition  Position = "BR"
 	UnspecifiedPosition  Position = ""
 )
+
+// defaultPosition is the position new AlertModels start with.
+var defaultPosition = TopLeftPosition
+
+// SetDefaultPosition changes the position used by AlertModels created after
+// the call, so apps that only ever use one corner don't need WithPosition on
+// every model. Invalid positions are ignored.
+// Note: this is not safe for concurrent use; call it once during program
+// initialization, before any AlertModel is constructed.
+func SetDefaultPosition(pos Position) {
+	if !pos.IsValid() {
+		return
+	}
+	defaultPosition = pos
+}
+
+// DefaultPosition returns the position new AlertModels start with.
+func DefaultPosition() Position {
+	return defaultPosition
+}
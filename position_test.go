@@ -0,0 +1,71 @@
+package bubbleup
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/muesli/reflow/ansi"
+)
+
+func TestSetDefaultPositionAppliesToNewModels(t *testing.T) {
+	defer SetDefaultPosition(DefaultPosition())
+	SetDefaultPosition(BottomRightPosition)
+
+	if pos := NewAlertModel(20, false, 10).position; pos != BottomRightPosition {
+		t.Errorf("new model position = %s, want %s", pos, BottomRightPosition)
+	}
+	m := raise(*NewAlertModel(20, false, 10), InfoKey, "hi")
+	if pos := m.activeAlert.position; pos != BottomRightPosition {
+		t.Errorf("alert position = %s, want %s", pos, BottomRightPosition)
+	}
+}
+
+func TestSetDefaultPositionIgnoresInvalid(t *testing.T) {
+	defer SetDefaultPosition(DefaultPosition())
+	SetDefaultPosition(BottomLeftPosition)
+	SetDefaultPosition(Position("middle"))
+
+	if pos := DefaultPosition(); pos != BottomLeftPosition {
+		t.Errorf("DefaultPosition() = %s, want %s", pos, BottomLeftPosition)
+	}
+}
+
+func TestCenterPositionsAreSymmetricWithEmoji(t *testing.T) {
+	for _, pos := range []Position{TopCenterPosition, BottomCenterPosition} {
+		m, _ := newTestModel()
+		m = raise(m.WithPosition(pos), InfoKey, "🎉 shipped 🚀")
+
+		for _, line := range strings.Split(stripANSI(m.Render(blank(40, 6))), "\n") {
+			if strings.TrimSpace(line) == "" {
+				continue
+			}
+			left := len(line) - len(strings.TrimLeft(line, " "))
+			right := len(line) - len(strings.TrimRight(line, " "))
+			if left != right || ansi.PrintableRuneWidth(line) != 40 {
+				t.Errorf("%s: margins %d/%d in %q", pos, left, right, line)
+			}
+		}
+	}
+}
+
+func TestOffsetsMoveAlertsOffEdges(t *testing.T) {
+	m, _ := newTestModel()
+	m = raise(m.WithPosition(TopLeftPosition).WithVerticalOffset(2).WithHorizontalOffset(3), InfoKey, "hi")
+
+	lines := strings.Split(stripANSI(m.Render(blank(40, 10))), "\n")
+	for i, line := range lines[:2] {
+		if strings.TrimSpace(line) != "" {
+			t.Errorf("line %d = %q, want it left clear", i, line)
+		}
+	}
+	if !strings.HasPrefix(lines[2], "   ╭") {
+		t.Errorf("line 2 = %q, want the box 3 columns in", lines[2])
+	}
+
+	// A large offset still keeps the alert on screen
+	m = m.WithVerticalOffset(50).WithHorizontalOffset(50)
+	lines = strings.Split(stripANSI(m.Render(blank(40, 10))), "\n")
+	if last := lines[len(lines)-1]; !strings.HasSuffix(last, "╯") {
+		t.Errorf("last line = %q, want the box clamped to the bottom right", last)
+	}
+}
@@ -0,0 +1,36 @@
+package bubbleup
+
+// PositionReference controls what width alerts are positioned against.
+type PositionReference int
+
+const (
+	// ReferenceContent positions alerts against the widest line of the
+	// content passed to Render, so a right aligned alert sits at the content's
+	// right edge even in a wider terminal. This is the default.
+	ReferenceContent PositionReference = iota
+
+	// ReferenceTerminal positions alerts against the terminal width from the
+	// last tea.WindowSizeMsg, padding narrower content out to the alert, e.g.
+	// to keep alerts in the terminal's corner whatever the content. It falls
+	// back to the content width while the terminal width is unknown.
+	ReferenceTerminal
+)
+
+// WithPositionReference returns a new AlertModel whose alerts and inbox
+// badge are positioned against ref. Defaults to ReferenceContent.
+// This is an immutable operation.
+func (m AlertModel) WithPositionReference(ref PositionReference) AlertModel {
+	m.positionReference = ref
+	return m
+}
+
+// referenceWidth returns the width alerts are positioned against, for
+// content contentWidth columns wide.
+func (m AlertModel) referenceWidth(contentWidth int) int {
+	if m.positionReference == ReferenceTerminal {
+		if cols := m.terminalWidth(); cols > 0 {
+			return cols
+		}
+	}
+	return contentWidth
+}
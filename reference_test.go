@@ -0,0 +1,45 @@
+package bubbleup
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// alertColumn returns the column the alert's top border starts at in the
+// rendering of m over content, or -1 if it isn't drawn.
+func alertColumn(m AlertModel, content string) int {
+	for _, line := range strings.Split(stripANSI(m.Render(content)), "\n") {
+		if i := strings.Index(line, "╭"); i >= 0 {
+			return len([]rune(line[:i]))
+		}
+	}
+	return -1
+}
+
+func TestPositionReferencePicksWidth(t *testing.T) {
+	m, _ := newTestModel()
+	m = m.WithPosition(TopRightPosition)
+	m = raise(m, InfoKey, "corner")
+	content := blank(30, 5)
+
+	contentX := alertColumn(m, content)
+	if contentX < 0 {
+		t.Fatal("expected the alert to be drawn")
+	}
+
+	// Until the terminal width is known, it falls back to the content
+	terminal := m.WithPositionReference(ReferenceTerminal)
+	if x := alertColumn(terminal, content); x != contentX {
+		t.Errorf("x without a terminal width = %d, want %d as with the content", x, contentX)
+	}
+
+	terminal = send(terminal, tea.WindowSizeMsg{Width: 60, Height: 5})
+	if x := alertColumn(terminal, content); x != contentX+30 {
+		t.Errorf("x against the terminal = %d, want %d", x, contentX+30)
+	}
+	if x := alertColumn(send(m, tea.WindowSizeMsg{Width: 60, Height: 5}), content); x != contentX {
+		t.Errorf("x against the content = %d, want it kept at %d", x, contentX)
+	}
+}
@@ -0,0 +1,63 @@
+package bubbleup
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// sequenceMsg is the tea.Msg used to start an alert sequence
+type sequenceMsg struct {
+	steps []alertMsg
+}
+
+// cancelSequenceMsg is the tea.Msg used to cancel an alert sequence
+type cancelSequenceMsg struct{}
+
+// NewAlertSequenceCmd returns the tea.Cmd that shows the alerts described by
+// specs one at a time, each appearing once the previous one times out or is
+// closed, e.g. for a guided flow. Steps that can't be shown, such as ones of
+// an unknown type or with an empty message, are skipped. Starting a new
+// sequence cancels any running one, as does showing an unrelated alert or
+// dismissing all alerts.
+func (m AlertModel) NewAlertSequenceCmd(specs []AlertSpec) tea.Cmd {
+	steps := make([]alertMsg, 0, len(specs))
+	for _, spec := range specs {
+		step := m.specMsg(spec)
+		step.sequenced = true
+		steps = append(steps, step)
+	}
+	return func() tea.Msg {
+		return sequenceMsg{steps: steps}
+	}
+}
+
+// CancelAlertSequenceCmd returns the tea.Cmd that cancels the running alert
+// sequence. The alert currently shown stays until it times out or is closed,
+// but no further alerts in the sequence appear.
+func (m AlertModel) CancelAlertSequenceCmd() tea.Cmd {
+	return func() tea.Msg {
+		return cancelSequenceMsg{}
+	}
+}
+
+// advanceSequence returns the tea.Cmd that shows the next alert in the
+// running sequence, if dismissed was part of it.
+func (m *AlertModel) advanceSequence(dismissed *alert) tea.Cmd {
+	if dismissed == nil || !dismissed.sequenced || len(m.sequence) == 0 {
+		return nil
+	}
+
+	next := m.sequence[0]
+	m.sequence = m.sequence[1:]
+	return func() tea.Msg {
+		return next
+	}
+}
+
+// hasInSequence reports whether an alert of type key is waiting in the
+// running sequence.
+func (m AlertModel) hasInSequence(key string) bool {
+	for _, step := range m.sequence {
+		if step.alertKey == key {
+			return true
+		}
+	}
+	return false
+}
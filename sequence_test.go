@@ -0,0 +1,90 @@
+package bubbleup
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// closeAlert presses esc on m and delivers whatever the model asks for next.
+func closeAlert(m AlertModel) AlertModel {
+	out, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	return send(out.(AlertModel), runCmd(cmd)...)
+}
+
+func sequenceModel() (AlertModel, *fakeClock) {
+	m, clock := newTestModel()
+	m = m.WithAllowEscToClose()
+	return send(m, m.NewAlertSequenceCmd([]AlertSpec{
+		{Key: InfoKey, Message: "first"},
+		{Key: InfoKey, Message: "second"},
+		{Key: InfoKey, Message: "third"},
+	})()), clock
+}
+
+func TestSequenceShowsNextOnlyAfterDismissal(t *testing.T) {
+	m, clock := sequenceModel()
+	if m.activeAlert == nil || m.activeAlert.message != "first" {
+		t.Fatal("expected the first alert of the sequence to show")
+	}
+
+	// Nothing moves the sequence on while the first alert is up
+	clock.advance(5 * time.Second)
+	if m = send(m, struct{}{}); m.activeAlert.message != "first" {
+		t.Fatalf("expected the first alert to stay, got %q", m.activeAlert.message)
+	}
+
+	if m = closeAlert(m); m.activeAlert == nil || m.activeAlert.message != "second" {
+		t.Fatal("expected the second alert once the first was closed")
+	}
+
+	// Timing out advances the sequence too
+	clock.advance(11 * time.Second)
+	if m = send(m, struct{}{}); m.activeAlert == nil || m.activeAlert.message != "third" {
+		t.Fatal("expected the third alert once the second timed out")
+	}
+
+	if m = closeAlert(m); m.activeAlert != nil {
+		t.Errorf("expected nothing after the last alert, got %q", m.activeAlert.message)
+	}
+}
+
+func TestCancelledSequenceStopsAfterCurrentAlert(t *testing.T) {
+	m, _ := sequenceModel()
+	m = send(m, m.CancelAlertSequenceCmd()())
+	if m.activeAlert == nil || m.activeAlert.message != "first" {
+		t.Fatal("expected cancelling to leave the current alert up")
+	}
+
+	if m = closeAlert(m); m.activeAlert != nil {
+		t.Errorf("expected no more alerts after cancelling, got %q", m.activeAlert.message)
+	}
+}
+
+func TestUnrelatedAlertEndsSequence(t *testing.T) {
+	m, _ := sequenceModel()
+	m = raise(m, ErrorKey, "unrelated")
+
+	if m = closeAlert(m); m.activeAlert != nil {
+		t.Errorf("expected the sequence to end, got %q", m.activeAlert.message)
+	}
+}
+
+func TestSequenceSkipsStepsThatCantShow(t *testing.T) {
+	m, _ := newTestModel()
+	m = m.WithAllowEscToClose()
+	m = send(m, m.NewAlertSequenceCmd([]AlertSpec{
+		{Key: "missing", Message: "unknown type"},
+		{Key: InfoKey, Message: ""},
+		{Key: InfoKey, Message: "first"},
+		{Key: "missing", Message: "unknown again"},
+		{Key: InfoKey, Message: "last"},
+	})())
+	if m.activeAlert == nil || m.activeAlert.message != "first" {
+		t.Fatal("expected the first valid step to show")
+	}
+	if m = closeAlert(m); m.activeAlert == nil || m.activeAlert.message != "last" {
+		t.Fatal("expected the invalid step in between to be skipped")
+	}
+}
@@ -0,0 +1,95 @@
+package bubbleup
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// WithSidebar returns a new AlertModel that shows alerts in a gutter width
+// columns wide on the given side, lipgloss.Left or lipgloss.Right, instead of
+// overlaying the content. Render narrows the content to ContentWidth columns
+// and places it alongside the gutter, and alerts wrap to fit the gutter. The
+// vertical part of the alert position still applies within the gutter. This
+// is an immutable operation.
+func (m AlertModel) WithSidebar(width int, side lipgloss.Position) AlertModel {
+	m.sidebarWidth = max(width, 0)
+	m.sidebarSide = side
+	return m.Reflow()
+}
+
+// ContentWidth returns the width left for the main content beside the
+// WithSidebar gutter, for rendering the content to fit. Without a sidebar it
+// is the terminal width. It returns 0 when the terminal width is unknown.
+func (m AlertModel) ContentWidth() int {
+	return max(m.terminalWidth()-m.sidebarWidth, 0)
+}
+
+// alertWidths returns the max and min widths alerts are laid out with,
+// narrowed to fit inside the WithSidebar gutter when set.
+func (m AlertModel) alertWidths() (width, minWidth int) {
+	if m.sidebarWidth <= 0 {
+		return m.width, m.minWidth
+	}
+
+	// Leave room for the border
+	width = max(m.sidebarWidth-2, 1)
+	return width, min(m.minWidth, width)
+}
+
+// alertOrigin returns the cell of the top-left corner of the active alert's
+// width by height box, as placed over cols by rows of content, or in the
+// WithSidebar gutter at the side of those columns. Rendering, mouse bounds
+// and DebugLayout all place the alert with it, so they always agree; over
+// content, that includes the WithEdgeInset it was last drawn with.
+func (m AlertModel) alertOrigin(width, height, cols, rows int) (x, y int) {
+	pos := m.activeAlert.position
+	if m.sidebarWidth <= 0 {
+		m.verticalOffset += m.activeAlert.inset
+		m.horizontalOffset += m.activeAlert.inset
+		y = m.startLineForPosition(pos, height, rows)
+		return m.columnForPosition(pos, width, cols), y
+	}
+
+	y = m.startLineForPosition(pos, height, rows)
+
+	x = m.columnForPosition(pos, width, m.sidebarWidth)
+	if m.sidebarSide != lipgloss.Left {
+		x += max(cols-m.sidebarWidth, 0)
+	}
+	return x, y
+}
+
+// renderSidebar renders content narrowed to make room for the WithSidebar
+// gutter, with the active alert placed in the gutter.
+func (m AlertModel) renderSidebar(content string) string {
+	contentSplit, contentWidth := getLines(content)
+	if w := m.ContentWidth(); w > 0 {
+		contentWidth = w
+	}
+
+	gutter := make([]string, len(contentSplit))
+	if m.shown() {
+		notifSplit, notifWidth := getLines(m.renderActiveAlert())
+		left, startLine := m.alertOrigin(notifWidth, len(notifSplit), contentWidth+m.sidebarWidth, len(gutter))
+		if m.sidebarSide != lipgloss.Left {
+			// Drawn into the gutter, which starts after the content
+			left -= contentWidth
+		}
+		for i := 0; i < len(notifSplit) && startLine+i < len(gutter); i++ {
+			gutter[startLine+i] = overlayAt("", notifSplit[i], left, notifWidth)
+		}
+	}
+
+	for i, line := range contentSplit {
+		line = padRight(cutRight(line, contentWidth), contentWidth)
+		side := padRight(cutRight(gutter[i], m.sidebarWidth), m.sidebarWidth)
+		if m.sidebarSide == lipgloss.Left {
+			contentSplit[i] = side + line
+		} else {
+			contentSplit[i] = line + side
+		}
+	}
+
+	return m.drawTopLayer(m.drawInboxBadge(strings.Join(contentSplit, "\n")))
+}
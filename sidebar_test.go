@@ -0,0 +1,45 @@
+package bubbleup
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// fullContent returns height lines of width x's, as content filling the
+// terminal.
+func fullContent(width, height int) string {
+	return strings.TrimSuffix(strings.Repeat(strings.Repeat("x", width)+"\n", height), "\n")
+}
+
+func TestSidebarNarrowsContentAndHoldsAlert(t *testing.T) {
+	m, _ := newTestModel()
+	m = m.WithSidebar(24, lipgloss.Right).WithPosition(TopLeftPosition)
+	m = send(m, tea.WindowSizeMsg{Width: 50, Height: 5})
+	m = raise(m, InfoKey, "in the gutter")
+
+	if w := m.ContentWidth(); w != 26 {
+		t.Fatalf("ContentWidth() = %d, want 26", w)
+	}
+	lines := strings.Split(stripANSI(m.Render(fullContent(50, 5))), "\n")
+	for i, line := range lines {
+		content, gutter := string([]rune(line)[:26]), string([]rune(line)[26:])
+		if content != strings.Repeat("x", 26) {
+			t.Errorf("line %d content = %q, want it narrowed to 26 columns", i, content)
+		}
+		if strings.Contains(gutter, "x") || lipgloss.Width(gutter) != 24 {
+			t.Errorf("line %d gutter = %q, want 24 columns without content", i, gutter)
+		}
+	}
+	if !strings.HasPrefix(string([]rune(lines[1])[26:]), "│ (i) in the gutter") {
+		t.Errorf("expected the alert in the gutter, got:\n%s", strings.Join(lines, "\n"))
+	}
+
+	// On the left, the gutter comes first
+	left := strings.Split(stripANSI(m.WithSidebar(24, lipgloss.Left).Render(fullContent(50, 5))), "\n")
+	if !strings.HasPrefix(left[0], "╭") || !strings.HasSuffix(left[0], strings.Repeat("x", 26)) {
+		t.Errorf("line 0 = %q, want the gutter before the content", left[0])
+	}
+}
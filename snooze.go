@@ -0,0 +1,127 @@
+package bubbleup
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// snoozeMsg is the tea.Msg used to snooze the alert with the given id
+type snoozeMsg struct {
+	id AlertID
+	d  time.Duration
+}
+
+// wakeMsg is the tea.Msg used to check for snoozed alerts that are due
+type wakeMsg struct{}
+
+// snoozedAlert is an alert hidden by SnoozeCmd, to be shown again at wakeAt
+type snoozedAlert struct {
+	msg    alertMsg
+	wakeAt time.Time
+}
+
+// SnoozeCmd returns the tea.Cmd that hides the active alert with the given id
+// and shows it again, with the same message and id, once d has passed, e.g.
+// for reminders. It is shown again for its full duration. While snoozed it
+// isn't shown or reported by HasActiveAlert, but it still counts as waiting
+// for HasActiveAlertOfType. The OnDismiss hook is told with DismissSnoozed.
+// In test mode it shows again on the first Update once d has passed.
+func (m AlertModel) SnoozeCmd(id AlertID, d time.Duration) tea.Cmd {
+	return func() tea.Msg {
+		return snoozeMsg{id: id, d: d}
+	}
+}
+
+// snooze hides the active alert if msg targets it, returning the tea.Cmd
+// that wakes it and shows the next alert of any sequence.
+func (m *AlertModel) snooze(msg snoozeMsg) tea.Cmd {
+	n := m.activeAlert
+	if n == nil || msg.id == "" || n.id != msg.id {
+		return nil
+	}
+	m.notifyDismiss(DismissSnoozed)
+	m.activeAlert = nil
+
+	// Copy before writing, since earlier copies of the model share the slice
+	snoozed := make([]snoozedAlert, 0, len(m.snoozed)+1)
+	for _, s := range m.snoozed {
+		if s.msg.id != msg.id {
+			snoozed = append(snoozed, s)
+		}
+	}
+	m.snoozed = append(snoozed, snoozedAlert{
+		msg: alertMsg{
+			id:       n.id,
+			alertKey: n.key,
+			msg:      n.message,
+			subtitle: n.subtitle,
+			font:     n.font,
+			onClick:  n.onClick,
+			dur:      n.dur,
+			metadata: n.metadata,
+			sticky:   n.sticky,
+			target:   n.target,
+			noWrap:   n.noWrap,
+			until:    n.until,
+			confirm:  n.confirm,
+			woken:    true,
+			created:  true,
+		},
+		wakeAt: m.getClock().Now().Add(msg.d),
+	})
+	return tea.Batch(m.wakeCmd(msg.d), m.advanceSequence(n))
+}
+
+// wakeCmd returns the tea.Cmd that checks for due snoozed alerts after d.
+// Test mode checks on every Update instead.
+func (m AlertModel) wakeCmd(d time.Duration) tea.Cmd {
+	if m.testMode {
+		return nil
+	}
+	return m.getClock().Tick(d, func(time.Time) tea.Msg {
+		return wakeMsg{}
+	})
+}
+
+// wake shows the first snoozed alert that is due, and checks again later
+// for any still snoozed.
+func (m AlertModel) wake() (tea.Model, tea.Cmd) {
+	now := m.getClock().Now()
+	var (
+		due    *alertMsg
+		next   time.Time
+		asleep []snoozedAlert
+	)
+	for _, s := range m.snoozed {
+		if due == nil && !s.wakeAt.After(now) {
+			due = &s.msg
+			continue
+		}
+		if next.IsZero() || s.wakeAt.Before(next) {
+			next = s.wakeAt
+		}
+		asleep = append(asleep, s)
+	}
+	m.snoozed = asleep
+
+	var again tea.Cmd
+	if !next.IsZero() {
+		again = m.wakeCmd(next.Sub(now))
+	}
+	if due == nil {
+		return m, again
+	}
+	out, cmd := m.Update(*due)
+	return out, tea.Batch(cmd, again)
+}
+
+// hasSnoozed reports whether an alert of type key is snoozed.
+func (m AlertModel) hasSnoozed(key string) bool {
+	for _, s := range m.snoozed {
+		if s.msg.alertKey == key {
+			return true
+		}
+	}
+	return false
+}
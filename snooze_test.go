@@ -0,0 +1,103 @@
+package bubbleup
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSnoozedAlertReappearsAfterDelay(t *testing.T) {
+	m, clock := newTestModel()
+	m, got := recordDismissals(m)
+	m = send(m, m.NewAlertCmdWithID("standup", InfoKey, "standup in 5")())
+
+	if m = send(m, m.SnoozeCmd("standup", time.Minute)()); m.HasActiveAlert() {
+		t.Fatal("expected the snoozed alert to be hidden")
+	}
+	if !m.HasActiveAlertOfType(InfoKey) {
+		t.Error("expected the snoozed alert to still count as waiting")
+	}
+
+	clock.advance(59 * time.Second)
+	if m = send(m, struct{}{}); m.HasActiveAlert() {
+		t.Fatal("expected the alert to stay hidden before the delay")
+	}
+	clock.advance(time.Second)
+	if m = send(m, struct{}{}); m.activeAlert == nil {
+		t.Fatal("expected the alert back once the delay passed")
+	}
+	if m.activeAlert.id != "standup" || m.activeAlert.message != "standup in 5" {
+		t.Errorf("alert = %s %q, want the snoozed content and id", m.activeAlert.id, m.activeAlert.message)
+	}
+	if len(*got) != 1 || (*got)[0].reason != DismissSnoozed {
+		t.Errorf("dismissals = %v, want one DismissSnoozed", *got)
+	}
+}
+
+func TestDismissAllReportsHiddenAlerts(t *testing.T) {
+	m, clock := newTestModel()
+	m, got := recordDismissals(m.WithDismissAllKey("ctrl+x").WithDoNotDisturbMode(DoNotDisturbQueue))
+	m = send(m, m.NewAlertCmdWithID("standup", InfoKey, "snoozed")())
+	m = send(m, m.SnoozeCmd("standup", time.Minute)())
+	m = send(m, m.NewAlertSequenceCmd([]AlertSpec{
+		{Key: WarnKey, Message: "shown"},
+		{Key: WarnKey, Message: "step 2"},
+	})())
+	m, _ = m.SetDoNotDisturb(true)
+	m = raise(m, ErrorKey, "held")
+
+	*got = nil
+	m = send(m, keyMsg("ctrl+x"))
+	reported := map[string]DismissReason{}
+	for _, d := range *got {
+		reported[d.spec.Message] = d.reason
+	}
+	for _, message := range []string{"shown", "held", "snoozed", "step 2"} {
+		if reason, ok := reported[message]; !ok || reason != DismissClosed {
+			t.Errorf("%q reported as %v (%v), want %s", message, reason, ok, DismissClosed)
+		}
+	}
+
+	// Nothing comes back afterwards
+	m, cmd := m.SetDoNotDisturb(false)
+	if cmd != nil {
+		t.Error("expected no held alert left to show")
+	}
+	clock.advance(2 * time.Minute)
+	if m = send(m, struct{}{}); m.activeAlert != nil {
+		t.Errorf("expected everything cleared, got %q", m.activeAlert.message)
+	}
+}
+
+func TestDismissAllWithNothingShown(t *testing.T) {
+	t.Run("held only", func(t *testing.T) {
+		m, _ := newTestModel()
+		m, got := recordDismissals(m.WithDismissAllKey("ctrl+x").WithDoNotDisturbMode(DoNotDisturbQueue))
+		m, _ = m.SetDoNotDisturb(true)
+		m = raise(m, ErrorKey, "held")
+
+		m = send(m, keyMsg("ctrl+x"))
+		if len(*got) != 1 || (*got)[0].reason != DismissClosed {
+			t.Errorf("dismissals = %v, want the held alert closed", *got)
+		}
+		if _, cmd := m.SetDoNotDisturb(false); cmd != nil {
+			t.Error("expected no held alert left to show")
+		}
+	})
+
+	t.Run("snoozed only", func(t *testing.T) {
+		m, clock := newTestModel()
+		m, got := recordDismissals(m.WithDismissAllKey("ctrl+x"))
+		m = send(m, m.NewAlertCmdWithID("standup", InfoKey, "snoozed")())
+		m = send(m, m.SnoozeCmd("standup", time.Minute)())
+
+		*got = nil
+		m = send(m, keyMsg("ctrl+x"))
+		if len(*got) != 1 || (*got)[0].reason != DismissClosed {
+			t.Errorf("dismissals = %v, want the snoozed alert closed", *got)
+		}
+		clock.advance(2 * time.Minute)
+		if m = send(m, struct{}{}); m.activeAlert != nil {
+			t.Error("expected the snoozed alert not to come back")
+		}
+	})
+}
@@ -0,0 +1,70 @@
+package bubbleup
+
+import (
+	"io"
+	"os"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Sounder plays audible feedback when an alert is shown. key is the alert
+// type key of the alert being shown.
+type Sounder interface {
+	Play(key string)
+}
+
+// SounderFunc adapts an ordinary function to a Sounder.
+type SounderFunc func(key string)
+
+// Play calls f(key).
+func (f SounderFunc) Play(key string) {
+	f(key)
+}
+
+// BellSounder is a Sounder that rings the terminal bell by writing the BEL
+// character to W, or to os.Stdout when W is nil.
+type BellSounder struct {
+	W io.Writer
+}
+
+// Play rings the bell, regardless of key.
+func (b BellSounder) Play(string) {
+	w := b.W
+	if w == nil {
+		w = os.Stdout
+	}
+	// A bell that fails to ring isn't worth reporting
+	_, _ = io.WriteString(w, "\a")
+}
+
+// WithSounder returns a new AlertModel that plays s whenever an alert of one
+// of the given type keys is shown. With no keys, s plays for every alert type.
+// Sounds are played from a tea.Cmd, outside the render loop.
+// This is an immutable operation.
+func (m AlertModel) WithSounder(s Sounder, keys ...string) AlertModel {
+	m.sounder = s
+	m.soundKeys = nil
+	if len(keys) > 0 {
+		m.soundKeys = make(map[string]bool, len(keys))
+		for _, key := range keys {
+			m.soundKeys[key] = true
+		}
+	}
+	return m
+}
+
+// soundCmd returns the tea.Cmd that plays the sound for an alert of type
+// key, or nil if no sound is configured for it.
+func (m AlertModel) soundCmd(key string) tea.Cmd {
+	if m.sounder == nil {
+		return nil
+	}
+	if m.soundKeys != nil && !m.soundKeys[key] {
+		return nil
+	}
+	sounder := m.sounder
+	return func() tea.Msg {
+		sounder.Play(key)
+		return nil
+	}
+}
@@ -0,0 +1,31 @@
+package bubbleup
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestSounderPlaysForConfiguredKeys(t *testing.T) {
+	var played []string
+	m, _ := newTestModel()
+	m = m.WithSounder(SounderFunc(func(key string) {
+		played = append(played, key)
+	}), ErrorKey)
+
+	for _, key := range []string{InfoKey, ErrorKey, WarnKey} {
+		_, cmd := m.Update(m.NewAlertCmd(key, "hello "+key)())
+		runCmd(cmd)
+	}
+	if want := []string{ErrorKey}; !reflect.DeepEqual(played, want) {
+		t.Errorf("played %v, want %v", played, want)
+	}
+}
+
+func TestBellSounderRingsBell(t *testing.T) {
+	var buf bytes.Buffer
+	BellSounder{W: &buf}.Play(InfoKey)
+	if buf.String() != "\a" {
+		t.Errorf("wrote %q, want a BEL", buf.String())
+	}
+}
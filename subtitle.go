@@ -0,0 +1,31 @@
+package bubbleup
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// NewAlertWithSubtitleCmd returns the tea.Cmd that raises an alert laid out
+// as a title, then a single line subtitle, then the body. The title and body
+// wrap as usual, while the subtitle never wraps and is cut with the ellipsis
+// when too wide. The body may be empty.
+func (m AlertModel) NewAlertWithSubtitleCmd(alertType, title, subtitle, body string) tea.Cmd {
+	message := title
+	if body != "" {
+		message += "\n" + body
+	}
+	return func() tea.Msg {
+		return alertMsg{alertKey: alertType, msg: message, subtitle: strings.ReplaceAll(subtitle, "\n", " ")}
+	}
+}
+
+// withSubtitle returns message with subtitle on its own line after the
+// first line, the title.
+func withSubtitle(message, subtitle string) string {
+	title, body, hasBody := strings.Cut(message, "\n")
+	if !hasBody {
+		return title + "\n" + subtitle
+	}
+	return title + "\n" + subtitle + "\n" + body
+}
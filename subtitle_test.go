@@ -0,0 +1,28 @@
+package bubbleup
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSubtitleTruncatesWhileBodyWraps(t *testing.T) {
+	m, _ := newTestModel()
+	m = send(m, m.NewAlertWithSubtitleCmd(InfoKey, "Deployed",
+		"to every production region at once", "all health checks passed")())
+
+	lines := strings.Split(stripANSI(m.activeAlert.render()), "\n")
+	want := []string{
+		"│ (i) Deployed       │",
+		"│     to every prod… │",
+		"│     all health     │",
+		"│     checks passed  │",
+	}
+	if len(lines) != len(want)+2 {
+		t.Fatalf("got %d lines, want %d:\n%s", len(lines), len(want)+2, strings.Join(lines, "\n"))
+	}
+	for i, line := range want {
+		if got := lines[i+1]; got != line {
+			t.Errorf("line %d = %q, want %q", i+1, got, line)
+		}
+	}
+}
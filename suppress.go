@@ -0,0 +1,49 @@
+package bubbleup
+
+import "time"
+
+// WithSuppressRepeatsWithin returns a new AlertModel that drops an alert when
+// an identical one appeared less than window ago, even if that one has since
+// been dismissed, e.g. to avoid re-showing the same error on every retry.
+// Alerts match as they do for repeat badges, using the WithDedupKey key when
+// set. Dropped alerts are reported to the OnDismiss hook with
+// DismissSuppressedAsRepeat. This is an immutable operation.
+func (m AlertModel) WithSuppressRepeatsWithin(window time.Duration) AlertModel {
+	m.suppressWithin = window
+	return m
+}
+
+// matchKey returns the key alerts are compared by for repeats, from the
+// WithDedupKey function when set, or the type and message otherwise.
+func (m AlertModel) matchKey(key, message string) string {
+	if m.dedupKey == nil {
+		return key + "\x00" + message
+	}
+	return m.dedupKey(key, message)
+}
+
+// suppressRepeat reports whether msg should be dropped because an identical
+// alert appeared within the WithSuppressRepeatsWithin window. Otherwise it
+// records msg as shown now.
+func (m *AlertModel) suppressRepeat(msg alertMsg) bool {
+	if m.suppressWithin <= 0 {
+		return false
+	}
+
+	now := m.getClock().Now()
+	key := m.matchKey(msg.alertKey, msg.msg)
+	if shown, ok := m.lastShown[key]; ok && now.Sub(shown) < m.suppressWithin {
+		return true
+	}
+
+	// Copy before writing, since earlier copies of the model share the map
+	lastShown := make(map[string]time.Time, len(m.lastShown)+1)
+	for k, t := range m.lastShown {
+		if now.Sub(t) < m.suppressWithin {
+			lastShown[k] = t
+		}
+	}
+	lastShown[key] = now
+	m.lastShown = lastShown
+	return false
+}
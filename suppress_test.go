@@ -0,0 +1,49 @@
+package bubbleup
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSuppressRepeatsWithinWindowAfterDismissal(t *testing.T) {
+	m, clock := newTestModel()
+	m, got := recordDismissals(m.WithSuppressRepeatsWithin(time.Minute))
+	m = raise(m, ErrorKey, "connection lost")
+
+	// Let the first alert expire before the retry raises it again
+	clock.advance(11 * time.Second)
+	if m = send(m, struct{}{}); m.activeAlert != nil {
+		t.Fatal("expected the first alert to expire")
+	}
+	if m = raise(m, ErrorKey, "connection lost"); m.activeAlert != nil {
+		t.Fatal("expected the repeat within the window to be suppressed")
+	}
+	if n := len(*got); n != 2 || (*got)[1].reason != DismissSuppressedAsRepeat {
+		t.Errorf("dismissals = %v, want the repeat reported as suppressed", *got)
+	}
+
+	// A different alert isn't a repeat
+	if m = raise(m, ErrorKey, "disk full"); m.activeAlert == nil {
+		t.Error("expected a different alert to show")
+	}
+
+	// Once the window has passed the alert shows again
+	clock.advance(time.Minute)
+	if m = raise(m, ErrorKey, "connection lost"); m.activeAlert == nil || m.activeAlert.message != "connection lost" {
+		t.Error("expected the alert to show again after the window")
+	}
+}
+
+func TestSuppressRepeatsUsesDedupKey(t *testing.T) {
+	m, clock := newTestModel()
+	m = m.WithSuppressRepeatsWithin(time.Minute).WithDedupKey(func(key, message string) string {
+		return key + strings.TrimRight(message, "0123456789 ")
+	})
+	m = raise(m, ErrorKey, "retry 1")
+	clock.advance(11 * time.Second)
+
+	if m = raise(send(m, struct{}{}), ErrorKey, "retry 2"); m.activeAlert != nil {
+		t.Errorf("active alert = %q, want the repeat by dedup key suppressed", m.activeAlert.message)
+	}
+}
@@ -0,0 +1,52 @@
+package bubbleup
+
+// WithTestMode returns a new AlertModel for use in tests only. Alerts show
+// fully faded in straight away and no ticks are scheduled; instead an alert
+// is treated as gone as soon as its time is up, checked against the model's
+// clock (see WithClock) whenever the model is queried or updated. Running
+// the returned commands is never needed for alerts to come and go.
+// This is an immutable operation.
+func (m AlertModel) WithTestMode() AlertModel {
+	m.testMode = true
+	return m
+}
+
+// shown reports whether there is an active alert that hasn't run out of time
+// in test mode. Nothing runs out of time while paused.
+func (m AlertModel) shown() bool {
+	if m.activeAlert == nil {
+		return false
+	}
+	return !m.testMode || m.paused || !m.activeAlert.expiredAt(m.getClock().Now(), m.maxLifetime)
+}
+
+// expireDue clears the active alert in test mode once its time is up,
+// showing the next alert of a sequence, as a tick would.
+func (m AlertModel) expireDue() AlertModel {
+	if !m.testMode || m.activeAlert == nil || m.shown() {
+		return m
+	}
+
+	if m.blurred {
+		m.notifyDismiss(DismissExpiredDuringBlur)
+	} else {
+		m.notifyDismiss(DismissExpired)
+	}
+	dismissed := m.activeAlert
+	m.activeAlert = nil
+	if next := m.advanceSequence(dismissed); next != nil {
+		out, _ := m.Update(next())
+		m = out.(AlertModel)
+	}
+	return m
+}
+
+// wakeDue shows snoozed alerts that are due in test mode, as a wake tick
+// would.
+func (m AlertModel) wakeDue() AlertModel {
+	if !m.testMode || m.paused || len(m.snoozed) == 0 {
+		return m
+	}
+	out, _ := m.wake()
+	return out.(AlertModel)
+}
@@ -0,0 +1,29 @@
+package bubbleup
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTestModeShowsAndExpiresWithoutTicks(t *testing.T) {
+	m, clock := newTestModel()
+	out, cmd := m.Update(m.NewAlertCmdFromSpec(AlertSpec{Key: InfoKey, Message: "quick", Duration: 1})())
+	m = out.(AlertModel)
+
+	for _, msg := range runCmd(cmd) {
+		if _, ok := msg.(tickMsg); ok {
+			t.Fatal("expected no ticks in test mode")
+		}
+	}
+	if m.activeAlert.curLerpStep != 1 {
+		t.Errorf("curLerpStep = %v, want the alert fully shown at once", m.activeAlert.curLerpStep)
+	}
+	assertContains(t, stripANSI(m.Render(blank(30, 4))), "quick")
+
+	// Gone once its second is up, without any message reaching Update
+	clock.advance(time.Second + time.Millisecond)
+	assertNotContains(t, stripANSI(m.Render(blank(30, 4))), "quick")
+	if len(m.AlertBlocks()) != 0 || m.Fingerprint() != 0 {
+		t.Error("expected the expired alert to be gone from every view")
+	}
+}
@@ -0,0 +1,107 @@
+package bubbleup
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// WithTickerMode returns a new AlertModel where a new alert no longer
+// replaces the one shown. Instead it waits its turn, and the box cycles
+// through the waiting alerts one at a time, moving on every interval, so the
+// footprint stays the same however many alerts there are. Each alert still
+// goes once its own duration has passed, whether it's on screen or waiting.
+// Repeats of the shown alert are still counted on its badge. An interval of
+// 0 turns ticker mode off. This is an immutable operation.
+func (m AlertModel) WithTickerMode(interval time.Duration) AlertModel {
+	m.tickerInterval = interval
+	return m
+}
+
+// queueInTicker adds the alert for msg to the ticker rather than letting it
+// replace the shown alert, reporting whether it did.
+func (m *AlertModel) queueInTicker(msg alertMsg) bool {
+	if m.tickerInterval <= 0 || !m.shown() {
+		return false
+	}
+	n := m.newAlert(msg)
+	if n == nil {
+		return true
+	}
+
+	// Copy before writing, since earlier copies of the model share the slice
+	ticker := make([]*alert, 0, len(m.ticker)+1)
+	m.ticker = append(append(ticker, m.ticker...), n)
+	return true
+}
+
+// rotateTicker shows the next waiting alert once the shown one has had its
+// interval, putting the shown one at the back of the ticker.
+func (m *AlertModel) rotateTicker(now time.Time) {
+	if m.activeAlert == nil || len(m.ticker) == 0 || now.Sub(m.rotatedAt) < m.tickerInterval {
+		return
+	}
+	waiting := m.waitingInTicker(now)
+	if len(waiting) == 0 {
+		m.ticker = nil
+		return
+	}
+	next := waiting[0]
+	m.ticker = append(waiting[1:], m.activeAlert)
+	m.activeAlert = next
+	m.rotatedAt = now
+	m.publishActive(AlertShown)
+}
+
+// promoteTicker shows the next waiting alert once the shown one is gone,
+// adding the tea.Cmd starting its tick chain to cmd.
+func (m AlertModel) promoteTicker(cmd tea.Cmd) (AlertModel, tea.Cmd) {
+	if len(m.ticker) == 0 || m.activeAlert != nil {
+		return m, cmd
+	}
+	now := m.getClock().Now()
+	waiting := m.waitingInTicker(now)
+	m.ticker = nil
+	if len(waiting) == 0 {
+		return m, cmd
+	}
+	m.activeAlert = waiting[0]
+	m.ticker = waiting[1:]
+	m.rotatedAt = now
+	m.publishActive(AlertShown)
+	m.tickID = nextTickID()
+	return m, tea.Batch(cmd, m.tickCmd())
+}
+
+// waitingInTicker returns a copy of the ticker without the alerts whose time
+// is up, reporting each of those as expired.
+func (m AlertModel) waitingInTicker(now time.Time) []*alert {
+	waiting := make([]*alert, 0, len(m.ticker)+1)
+	for _, n := range m.ticker {
+		if n.expiredAt(now, m.maxLifetime) {
+			m.notifyWaiting(n, DismissExpired)
+			continue
+		}
+		waiting = append(waiting, n)
+	}
+	return waiting
+}
+
+// notifyWaiting reports the dismissal of an alert waiting in the ticker to
+// the OnDismiss hook and the event sink.
+func (m AlertModel) notifyWaiting(n *alert, reason DismissReason) {
+	m.publish(AlertDismissed, n.spec(), reason)
+	if m.onDismiss != nil {
+		m.onDismiss(n.spec(), reason)
+	}
+}
+
+// hasInTicker reports whether an alert of type key is waiting in the ticker.
+func (m AlertModel) hasInTicker(key string) bool {
+	for _, n := range m.ticker {
+		if n.key == key {
+			return true
+		}
+	}
+	return false
+}
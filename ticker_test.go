@@ -0,0 +1,43 @@
+package bubbleup
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTickerCyclesWaitingAlerts(t *testing.T) {
+	m, clock := newTestModel()
+	m = m.WithTickerMode(time.Second)
+	m = raise(m, InfoKey, "first")
+	m = raise(m, WarnKey, "second")
+
+	if m.activeAlert.message != "first" || len(m.ticker) != 1 {
+		t.Fatal("expected the second alert to wait its turn")
+	}
+	for _, want := range []string{"second", "first", "second"} {
+		clock.advance(time.Second)
+		if m = send(m, struct{}{}); m.activeAlert == nil || m.activeAlert.message != want {
+			t.Fatalf("expected %q after the interval", want)
+		}
+	}
+}
+
+func TestDismissAllReportsTicker(t *testing.T) {
+	m, clock := newTestModel()
+	m, got := recordDismissals(m.WithTickerMode(time.Second).WithDismissAllKey("ctrl+x"))
+	m = raise(m, InfoKey, "shown")
+	m = raise(m, WarnKey, "waiting")
+
+	m = send(m, keyMsg("ctrl+x"))
+	reported := reportedReasons(*got)
+	for _, message := range []string{"shown", "waiting"} {
+		if reason, ok := reported[message]; !ok || reason != DismissClosed {
+			t.Errorf("%q reported as %v (%v), want %s", message, reason, ok, DismissClosed)
+		}
+	}
+
+	clock.advance(2 * time.Second)
+	if m = send(m, struct{}{}); m.activeAlert != nil {
+		t.Errorf("expected the ticker cleared, got %q", m.activeAlert.message)
+	}
+}
@@ -0,0 +1,34 @@
+package bubbleup
+
+import "time"
+
+// TimerMode controls whether updates to an alert restart its countdown.
+type TimerMode int
+
+const (
+	// TimerSinceLastUpdate restarts an alert's countdown each time it is
+	// repeated or updated in place, e.g. with SetStatusCmd or a reused ID,
+	// so frequently updated alerts stay up until the updates stop. Appends
+	// only restart it with WithRefreshOnAppend. This is the default.
+	TimerSinceLastUpdate TimerMode = iota
+
+	// TimerAbsolute counts an alert's duration from when it first showed,
+	// however often it is updated, e.g. for progress alerts that should go
+	// away on time. It overrides WithRefreshOnAppend.
+	TimerAbsolute
+)
+
+// WithTimerMode returns a new AlertModel whose alerts count down according
+// to mode. Defaults to TimerSinceLastUpdate. This is an immutable operation.
+func (m AlertModel) WithTimerMode(mode TimerMode) AlertModel {
+	m.timerMode = mode
+	return m
+}
+
+// restartTimer restarts n's countdown for dur, unless in TimerAbsolute mode.
+func (m AlertModel) restartTimer(n *alert, dur time.Duration) {
+	if m.timerMode == TimerAbsolute {
+		return
+	}
+	n.deathTime = m.getClock().Now().Add(dur + m.dismissOnIdle)
+}
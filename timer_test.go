@@ -0,0 +1,47 @@
+package bubbleup
+
+import (
+	"testing"
+	"time"
+)
+
+// updateMidway shows an alert, updates it in place 6 seconds into its 10
+// second countdown and reports whether it is still up 11 seconds after it
+// first showed.
+func updateMidway(mode TimerMode, show, update func(AlertModel) AlertModel) bool {
+	m, clock := newTestModel()
+	m = show(m.WithTimerMode(mode))
+	clock.advance(6 * time.Second)
+	m = update(m)
+	clock.advance(5 * time.Second)
+	return send(m, struct{}{}).activeAlert != nil
+}
+
+// timerUpdates are the ways an alert is updated in place, each as the
+// alert shown and its update.
+var timerUpdates = map[string][2]func(AlertModel) AlertModel{
+	"repeat": {
+		func(m AlertModel) AlertModel { return raise(m, InfoKey, "syncing") },
+		func(m AlertModel) AlertModel { return raise(m, InfoKey, "syncing") },
+	},
+	"reused ID": {
+		func(m AlertModel) AlertModel { return send(m, m.NewAlertCmdWithID("job", InfoKey, "50%")()) },
+		func(m AlertModel) AlertModel { return send(m, m.NewAlertCmdWithID("job", InfoKey, "60%")()) },
+	},
+}
+
+func TestTimerSinceLastUpdateRestartsCountdown(t *testing.T) {
+	for name, u := range timerUpdates {
+		if !updateMidway(TimerSinceLastUpdate, u[0], u[1]) {
+			t.Errorf("%s: expected the update to restart the countdown", name)
+		}
+	}
+}
+
+func TestTimerAbsoluteKeepsCountdown(t *testing.T) {
+	for name, u := range timerUpdates {
+		if updateMidway(TimerAbsolute, u[0], u[1]) {
+			t.Errorf("%s: expected the alert to expire 10 seconds after it first showed", name)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package bubbleup
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// WithTerminalTitle returns a new AlertModel that also puts the message of
+// alerts of the given types in the terminal window title, so they can be
+// noticed from another tab, and sets the title back to restore once they go.
+// Pass your app's own title as restore, since a terminal's title can't be
+// read back. With no keys, every alert type sets the title. This is best
+// effort: terminals that don't support titles ignore it. The title changes
+// through commands returned from Update. This is an immutable operation.
+func (m AlertModel) WithTerminalTitle(restore string, keys ...string) AlertModel {
+	m.terminalTitle = true
+	m.titleRestore = restore
+	m.title = restore
+	m.titleKeys = nil
+	if len(keys) > 0 {
+		m.titleKeys = make(map[string]bool, len(keys))
+		for _, key := range keys {
+			m.titleKeys[key] = true
+		}
+	}
+	return m
+}
+
+// syncTitle adds the tea.Cmd setting the terminal title to cmd when the
+// WithTerminalTitle title should change for the alert now shown.
+func (m AlertModel) syncTitle(cmd tea.Cmd) (tea.Model, tea.Cmd) {
+	if !m.terminalTitle {
+		return m, cmd
+	}
+
+	title := m.titleRestore
+	if m.shown() && (m.titleKeys == nil || m.titleKeys[m.activeAlert.key]) {
+		title = strings.ReplaceAll(m.activeAlert.message, "\n", " ")
+	}
+	if title == m.title {
+		return m, cmd
+	}
+	m.title = title
+	return m, tea.Batch(cmd, tea.SetWindowTitle(title))
+}
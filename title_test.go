@@ -0,0 +1,70 @@
+package bubbleup
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// titleApp raises one alert, lets the alert model set the title, then quits
+// once the alert has been closed.
+type titleApp struct {
+	alert AlertModel
+}
+
+func (a titleApp) Init() tea.Cmd {
+	return a.alert.NewAlertCmd(ErrorKey, "build\nfailed")
+}
+
+func (a titleApp) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	out, cmd := a.alert.Update(msg)
+	a.alert = out.(AlertModel)
+	switch msg.(type) {
+	case alertMsg:
+		// Close it a few frames later, since the renderer only writes the
+		// latest title of each frame, so the original title comes back
+		return a, tea.Batch(cmd, tea.Tick(100*time.Millisecond, func(time.Time) tea.Msg {
+			return tea.KeyMsg{Type: tea.KeyEsc}
+		}))
+	case tea.KeyMsg:
+		return a, tea.Sequence(cmd, tea.Quit)
+	}
+	return a, cmd
+}
+
+func (a titleApp) View() string {
+	return ""
+}
+
+func TestTerminalTitleEmitsOSC(t *testing.T) {
+	m, _ := newTestModel()
+	var out bytes.Buffer
+	p := tea.NewProgram(titleApp{alert: m.WithAllowEscToClose().WithTerminalTitle("my app")},
+		tea.WithInput(nil), tea.WithOutput(&out), tea.WithoutSignalHandler())
+	if _, err := p.Run(); err != nil {
+		t.Fatal(err)
+	}
+
+	set := strings.Index(out.String(), "\x1b]2;build failed\a")
+	restore := strings.Index(out.String(), "\x1b]2;my app\a")
+	if set < 0 || restore < set {
+		t.Errorf("expected the alert's title and then the restored one, got %q", out.String())
+	}
+}
+
+func TestTerminalTitleOnlyForChosenTypes(t *testing.T) {
+	m, _ := newTestModel()
+	m = m.WithTerminalTitle("my app", ErrorKey)
+
+	out, cmd := m.Update(m.NewAlertCmd(InfoKey, "saved")())
+	if msgs := runCmd(cmd); len(msgs) != 0 {
+		t.Errorf("expected no title change for an Info alert, got %v", msgs)
+	}
+	_, cmd = out.(AlertModel).Update(m.NewAlertCmd(ErrorKey, "failed")())
+	if msgs := runCmd(cmd); len(msgs) != 1 || msgs[0] != tea.SetWindowTitle("failed")() {
+		t.Errorf("expected the Error alert to set the title, got %v", msgs)
+	}
+}
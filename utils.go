@@ -2,6 +2,7 @@ package bubbleup
 
 import (
 	"bytes"
+	"fmt"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
@@ -106,7 +107,29 @@ func cutRight(s string, keepWidth int) string {
 	return b.String()
 }
 
-// hangingWrap wraps text with a prefix to provide hanging indents
+// padRight pads s with spaces up to width printable cells.
+func padRight(s string, width int) string {
+	w := ansi.PrintableRuneWidth(s)
+	if w >= width {
+		return s
+	}
+	return s + strings.Repeat(" ", width-w)
+}
+
+// fitLines pads or cuts every line of s to exactly width printable cells.
+func fitLines(s string, width int) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if ansi.PrintableRuneWidth(line) > width {
+			line = cutRight(line, width)
+		}
+		lines[i] = padRight(line, width)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// hangingWrap wraps text with a prefix to provide hanging indents. Newlines
+// in msg are hard breaks: each line is wrapped on its own.
 func hangingWrap(prefix, msg string, textWidth int) string {
 	prefix = prefix + " "
 	indentW := lipgloss.Width(prefix)
@@ -116,16 +139,227 @@ func hangingWrap(prefix, msg string, textWidth int) string {
 		return prefix + msg
 	}
 
-	// Wrap message to the available width.
+	// Wrap each line of the message to the available width.
 	// wordwrap.WrapString wraps on spaces; it will still break long tokens if needed.
-	wrapped := wordwrap.String(msg, avail)
+	var lines []string
+	for _, line := range strings.Split(msg, "\n") {
+		lines = append(lines, strings.Split(wordwrap.String(line, avail), "\n")...)
+	}
 
 	// Add hanging indent to subsequent lines.
 	indent := strings.Repeat(" ", indentW)
-	lines := strings.Split(wrapped, "\n")
 	for i := 1; i < len(lines); i++ {
 		lines[i] = indent + lines[i]
 	}
 
 	return prefix + strings.Join(lines, "\n")
 }
+
+// hangingLines is like hangingWrap, but keeps msg's own line breaks instead
+// of wrapping, cutting lines wider than textWidth with ellipsis.
+func hangingLines(prefix, msg string, textWidth int, ellipsis string) string {
+	prefix = prefix + " "
+	indent := strings.Repeat(" ", lipgloss.Width(prefix))
+	lines := strings.Split(msg, "\n")
+	for i, line := range lines {
+		if i == 0 {
+			line = prefix + line
+		} else {
+			line = indent + line
+		}
+		lines[i] = truncate(line, textWidth, ellipsis)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// truncate shortens s to at most maxWidth printable cells, ending it with
+// tail when anything was cut. It works on whole runes and keeps ANSI escape
+// sequences intact, so it never splits a multi-byte character or a style.
+func truncate(s string, maxWidth int, tail string) string {
+	if ansi.PrintableRuneWidth(s) <= maxWidth {
+		return s
+	}
+
+	keep := maxWidth - ansi.PrintableRuneWidth(tail)
+	if keep < 0 {
+		// Not even the tail fits, so cut the tail itself
+		return cutRight(tail, maxWidth)
+	}
+
+	return cutRight(s, keep) + tail
+}
+
+// truncateRunes shortens s to at most n printable runes, ending it with tail
+// when anything was cut. ANSI escape sequences are kept whole and don't count
+// towards n.
+func truncateRunes(s string, n int, tail string) string {
+	var (
+		b      strings.Builder
+		count  int
+		isAnsi bool
+	)
+	for i, c := range s {
+		switch {
+		case c == ansi.Marker:
+			isAnsi = true
+		case isAnsi:
+			if ansi.IsTerminator(c) {
+				isAnsi = false
+			}
+		default:
+			if count == n {
+				// Keep any styling that follows, so resets still apply
+				return b.String() + tail + ansiOnly(s[i:])
+			}
+			count++
+		}
+		b.WriteRune(c)
+	}
+	return s
+}
+
+// ansiOnly returns just the ANSI escape sequences in s.
+func ansiOnly(s string) string {
+	var (
+		b      strings.Builder
+		isAnsi bool
+	)
+	for _, c := range s {
+		if c == ansi.Marker {
+			isAnsi = true
+		}
+		if isAnsi {
+			b.WriteRune(c)
+			if c != ansi.Marker && ansi.IsTerminator(c) {
+				isAnsi = false
+			}
+		}
+	}
+	return b.String()
+}
+
+// stripANSI returns s without its ANSI escape sequences.
+func stripANSI(s string) string {
+	var (
+		b      strings.Builder
+		isAnsi bool
+	)
+	for _, c := range s {
+		if c == ansi.Marker {
+			isAnsi = true
+		}
+		if !isAnsi {
+			b.WriteRune(c)
+		} else if c != ansi.Marker && ansi.IsTerminator(c) {
+			isAnsi = false
+		}
+	}
+	return b.String()
+}
+
+// limitLines keeps the first limit-1 lines of s and replaces the remainder
+// with an indicator noting how many lines were hidden. The indicator is
+// indented by indentW to line up with hanging-wrapped continuation lines,
+// and truncated to maxWidth. ellipsis marks both the indicator and any cut.
+func limitLines(s string, limit, indentW, maxWidth int, ellipsis string) string {
+	lines := strings.Split(s, "\n")
+	if len(lines) <= limit {
+		return s
+	}
+
+	hidden := len(lines) - (limit - 1)
+	indicator := fmt.Sprintf("%s%s (+%d lines)", strings.Repeat(" ", indentW), ellipsis, hidden)
+	indicator = truncate(indicator, maxWidth, ellipsis)
+
+	return strings.Join(append(lines[:limit-1], indicator), "\n")
+}
+
+// limitLinesTail is like limitLines, but keeps the last limit-1 lines of s so
+// the latest text stays visible. The indicator takes the first line, after
+// head, which keeps the alert's prefix in place.
+func limitLinesTail(s string, limit int, head string, maxWidth int, ellipsis string) string {
+	lines := strings.Split(s, "\n")
+	if len(lines) <= limit {
+		return s
+	}
+
+	hidden := len(lines) - (limit - 1)
+	indicator := fmt.Sprintf("%s%s (+%d lines)", head, ellipsis, hidden)
+	indicator = truncate(indicator, maxWidth, ellipsis)
+
+	return strings.Join(append([]string{indicator}, lines[hidden:]...), "\n")
+}
+
+// sanitize strips control characters and escape sequences that could move
+// the cursor or otherwise corrupt the overlay. Newlines and SGR color/style
+// sequences are kept, and tabs become spaces.
+func sanitize(s string) string {
+	var b strings.Builder
+	runes := []rune(s)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r == '\n':
+			b.WriteRune(r)
+		case r == '\t':
+			b.WriteRune(' ')
+		case r == ansi.Marker:
+			end := escapeEnd(runes, i)
+			if seq := string(runes[i:end]); isSGR(seq) {
+				b.WriteString(seq)
+			}
+			i = end - 1
+		case r < 0x20 || r == 0x7f || (r >= 0x80 && r < 0xa0):
+			// Drop other C0 and C1 control characters
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// escapeEnd returns the index just past the escape sequence starting at
+// runes[start].
+func escapeEnd(runes []rune, start int) int {
+	i := start + 1
+	if i >= len(runes) {
+		return i
+	}
+	switch runes[i] {
+	case '[':
+		// CSI: parameters and intermediates, then a final byte
+		for i++; i < len(runes); i++ {
+			if runes[i] >= 0x40 && runes[i] <= 0x7e {
+				return i + 1
+			}
+		}
+		return i
+	case ']', 'P', '_', '^':
+		// OSC and other strings, ended by BEL or ST (ESC \)
+		for i++; i < len(runes); i++ {
+			if runes[i] == '\a' {
+				return i + 1
+			}
+			if runes[i] == ansi.Marker && i+1 < len(runes) && runes[i+1] == '\\' {
+				return i + 2
+			}
+		}
+		return i
+	default:
+		return i + 1
+	}
+}
+
+// isSGR reports whether seq is a Select Graphic Rendition sequence, which only
+// changes colors and text style.
+func isSGR(seq string) bool {
+	if !strings.HasPrefix(seq, "\x1b[") || !strings.HasSuffix(seq, "m") {
+		return false
+	}
+	for _, r := range seq[2 : len(seq)-1] {
+		if (r < '0' || r > '9') && r != ';' && r != ':' {
+			return false
+		}
+	}
+	return true
+}
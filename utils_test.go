@@ -0,0 +1,74 @@
+package bubbleup
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/muesli/reflow/ansi"
+)
+
+func TestTruncateKeepsRunesAndEscapesWhole(t *testing.T) {
+	inputs := []string{
+		"🎉🎉 party 🎉 time 👍🏽",
+		"日本語のテキストです",
+		"\x1b[31mred\x1b[0m and \x1b[1;32mbold green\x1b[0m text",
+		"\x1b[38;2;255;0;0m🔥 hot\x1b[0m 🧊 cold",
+	}
+	for _, s := range inputs {
+		for width := 0; width <= ansi.PrintableRuneWidth(s)+1; width++ {
+			got := truncate(s, width, "…")
+			if !utf8.ValidString(got) {
+				t.Errorf("truncate(%q, %d) = %q, not valid UTF-8", s, width, got)
+			}
+			if w := ansi.PrintableRuneWidth(got); w > width {
+				t.Errorf("truncate(%q, %d) = %q, %d wide", s, width, got, w)
+			}
+			assertWholeEscapes(t, s, got)
+		}
+	}
+}
+
+func TestTruncateRunesKeepsRunesAndEscapesWhole(t *testing.T) {
+	s := "\x1b[35m✨ sparkle\x1b[0m ✨✨ done"
+	for n := 0; n <= utf8.RuneCountInString(stripANSI(s)); n++ {
+		got := truncateRunes(s, n, "…")
+		if !utf8.ValidString(got) {
+			t.Errorf("truncateRunes(%q, %d) = %q, not valid UTF-8", s, n, got)
+		}
+		assertWholeEscapes(t, s, got)
+	}
+}
+
+func TestLimitLinesIndicatorFitsWideRunes(t *testing.T) {
+	got := limitLines("一\n二\n三\n四", 2, 2, 9, "…")
+	last := got[strings.LastIndexByte(got, '\n')+1:]
+	if !utf8.ValidString(last) || ansi.PrintableRuneWidth(last) > 9 {
+		t.Errorf("indicator %q doesn't fit 9 columns", last)
+	}
+}
+
+// assertWholeEscapes fails the test if got has an escape sequence that isn't
+// one of those in s, other than a reset.
+func assertWholeEscapes(t *testing.T, s, got string) {
+	t.Helper()
+	for _, seq := range strings.SplitAfter(ansiOnly(got), "m") {
+		if seq != "" && seq != "\x1b[0m" && !strings.Contains(s, seq) {
+			t.Errorf("%q has escape %q, not found in %q", got, seq, s)
+		}
+	}
+}
+
+func TestSanitizeNeutralizesControlSequences(t *testing.T) {
+	for _, tt := range []struct{ in, want string }{
+		{"50%\rdone", "50%done"},
+		{"up\x1b[2Ahere", "uphere"},
+		{"title\x1b]0;pwned\a!", "title!"},
+		{"a\tb\nc\x7f", "a b\nc"},
+		{"\x1b[31mred\x1b[0m", "\x1b[31mred\x1b[0m"},
+	} {
+		if got := sanitize(tt.in); got != tt.want {
+			t.Errorf("sanitize(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package bubbleup
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Notifier delivers messages to a running BubbleTea program.
+// *tea.Program satisfies it.
+type Notifier interface {
+	Send(msg tea.Msg)
+}
+
+// levelKeys maps recognized log level prefixes to alert type keys.
+var levelKeys = map[string]string{
+	"DEBUG":   DebugKey,
+	"INFO":    InfoKey,
+	"WARN":    WarnKey,
+	"WARNING": WarnKey,
+	"ERR":     ErrorKey,
+	"ERROR":   ErrorKey,
+}
+
+// alertWriter is the io.Writer returned by NewAlertWriter.
+type alertWriter struct {
+	notifier Notifier
+
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+// NewAlertWriter returns an io.Writer that raises an alert through notifier
+// for every line written to it, so an existing logger can be pointed at the
+// TUI. A leading level such as "ERROR:", "[warn]" or "DEBUG" picks the
+// alert type and is stripped from the message; lines without one become
+// Info alerts. Partial lines are held until their newline arrives.
+// It is safe for concurrent use.
+func (m AlertModel) NewAlertWriter(notifier Notifier) io.Writer {
+	return &alertWriter{notifier: notifier}
+}
+
+// Write raises an alert for each complete line in p.
+func (w *alertWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.buf.Write(p)
+	for {
+		line, err := w.buf.ReadString('\n')
+		if err != nil {
+			// Incomplete line, keep it for the next write
+			w.buf.Reset()
+			w.buf.WriteString(line)
+			break
+		}
+		w.notify(strings.TrimRight(line, "\r\n"))
+	}
+
+	return len(p), nil
+}
+
+// notify raises the alert for a single log line.
+func (w *alertWriter) notify(line string) {
+	key, msg := parseLogLine(line)
+	if msg == "" {
+		return
+	}
+	w.notifier.Send(alertMsg{alertKey: key, msg: msg})
+}
+
+// parseLogLine splits a leading log level off line, returning the matching
+// alert type key and the rest of the line. Lines without a recognized level
+// are Info alerts.
+func parseLogLine(line string) (key, msg string) {
+	line = strings.TrimSpace(line)
+
+	level, rest, _ := strings.Cut(line, " ")
+	level = strings.Trim(level, "[]:")
+	if key, ok := levelKeys[strings.ToUpper(level)]; ok {
+		return key, strings.TrimSpace(rest)
+	}
+
+	return InfoKey, line
+}
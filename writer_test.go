@@ -0,0 +1,62 @@
+package bubbleup
+
+import (
+	"fmt"
+	"log"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// recordingNotifier is a Notifier keeping every message sent to it.
+type recordingNotifier struct {
+	msgs []tea.Msg
+}
+
+func (n *recordingNotifier) Send(msg tea.Msg) {
+	n.msgs = append(n.msgs, msg)
+}
+
+func TestAlertWriterParsesLevels(t *testing.T) {
+	var n recordingNotifier
+	m, _ := newTestModel()
+	w := m.NewAlertWriter(&n)
+
+	fmt.Fprint(w, "ERROR: disk full\n[warn] low memory\ndebug cache miss\n")
+	fmt.Fprint(w, "plain line\nINFO")
+	fmt.Fprint(w, ": split across writes\n\n")
+
+	want := []struct{ key, msg string }{
+		{ErrorKey, "disk full"},
+		{WarnKey, "low memory"},
+		{DebugKey, "cache miss"},
+		{InfoKey, "plain line"},
+		{InfoKey, "split across writes"},
+	}
+	if len(n.msgs) != len(want) {
+		t.Fatalf("got %d alerts, want %d: %v", len(n.msgs), len(want), n.msgs)
+	}
+	for i, w := range want {
+		msg := n.msgs[i].(alertMsg)
+		if msg.alertKey != w.key || msg.msg != w.msg {
+			t.Errorf("alert %d = %s %q, want %s %q", i, msg.alertKey, msg.msg, w.key, w.msg)
+		}
+	}
+
+	// The messages raise alerts of those types
+	last := send(m, n.msgs...)
+	if !last.HasVisibleAlertOfType(InfoKey) || last.activeAlert.message != "split across writes" {
+		t.Error("expected the last line to show as an Info alert")
+	}
+}
+
+func TestAlertWriterWithLogger(t *testing.T) {
+	var n recordingNotifier
+	m, _ := newTestModel()
+	logger := log.New(m.NewAlertWriter(&n), "", 0)
+
+	logger.Println("WARNING retrying upload")
+	if len(n.msgs) != 1 || n.msgs[0].(alertMsg).alertKey != WarnKey {
+		t.Errorf("got %v, want one Warn alert", n.msgs)
+	}
+}